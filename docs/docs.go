@@ -15,45 +15,31 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Login with email and password",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/metrics/clicks": {
+            "get": {
+                "description": "Get counters for the asynchronous click recording queue",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Login user",
-                "parameters": [
-                    {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.LoginRequest"
-                        }
-                    }
+                    "metrics"
                 ],
+                "summary": "Click queue metrics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponse"
+                            "$ref": "#/definitions/url.ClickQueueMetricsResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                            "$ref": "#/definitions/common.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
@@ -61,55 +47,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Refresh access token using refresh token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/reserved-codes": {
+            "get": {
+                "description": "Get the short codes and words admins have taken out of use. Route names and the configured\nreserved words and blocklist apply as well but are not listed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Refresh access token",
-                "parameters": [
-                    {
-                        "description": "Refresh token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.RefreshTokenRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Get reserved codes",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.RefreshTokenResponse"
+                            "$ref": "#/definitions/admin.GetReservedCodesResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                            "$ref": "#/definitions/common.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/auth/register": {
+            },
             "post": {
-                "description": "Register a new user",
+                "description": "Take a short code (type reserved) or every code containing a word (type blocked) out of use for\nnew links. Existing links keep working.",
                 "consumes": [
                     "application/json"
                 ],
@@ -117,17 +87,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register user",
+                "summary": "Reserve code",
                 "parameters": [
                     {
-                        "description": "Registration details",
+                        "description": "Code details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterRequest"
+                            "$ref": "#/definitions/admin.CreateReservedCodeRequest"
                         }
                     }
                 ],
@@ -135,7 +105,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterResponse"
+                            "$ref": "#/definitions/admin.ReservedCodeResponse"
                         }
                     },
                     "400": {
@@ -144,6 +114,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/common.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
@@ -153,33 +135,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
-            "get": {
-                "description": "Ping",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/reserved-codes/{id}": {
+            "delete": {
+                "description": "Make a reserved code or blocked word available again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ping"
+                    "admin"
+                ],
+                "summary": "Delete reserved code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Reserved code ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Ping",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/admin.DeleteReservedCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/urls": {
-            "get": {
-                "description": "Get URLs with pagination and filtering",
+        "/auth/login": {
+            "post": {
+                "description": "Login with email and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -187,60 +198,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
+                    "auth"
                 ],
-                "summary": "Get URLs",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by active status",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "created_at",
-                        "description": "Sort field",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "default": "desc",
-                        "description": "Sort direction",
-                        "name": "sort_dir",
-                        "in": "query"
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.GetURLsResponse"
+                            "$ref": "#/definitions/auth.LoginResponse"
                         }
                     },
                     "400": {
@@ -248,11 +224,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.ValidationErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/auth/refresh": {
             "post": {
-                "description": "Create a new short URL",
+                "description": "Refresh access token using refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -260,25 +244,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
+                    "auth"
                 ],
-                "summary": "Create URL",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "description": "URL creation details",
+                        "description": "Refresh token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/url.CreateURLRequest"
+                            "$ref": "#/definitions/auth.RefreshTokenRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.CreateURLResponse"
+                            "$ref": "#/definitions/auth.RefreshTokenResponse"
                         }
                     },
                     "400": {
@@ -287,8 +271,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/common.ValidationErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
@@ -296,9 +280,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/urls/public": {
+        "/auth/register": {
             "post": {
-                "description": "Create a new short URL without authentication",
+                "description": "Register a new user",
                 "consumes": [
                     "application/json"
                 ],
@@ -306,17 +290,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
+                    "auth"
                 ],
-                "summary": "Create Public URL",
+                "summary": "Register user",
                 "parameters": [
                     {
-                        "description": "URL creation details",
+                        "description": "Registration details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/url.CreateURLRequest"
+                            "$ref": "#/definitions/auth.RegisterRequest"
                         }
                     }
                 ],
@@ -324,7 +308,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/url.CreateURLResponse"
+                            "$ref": "#/definitions/auth.RegisterResponse"
                         }
                     },
                     "400": {
@@ -342,51 +326,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/urls/{id}": {
+        "/campaigns": {
             "get": {
-                "description": "Get a specific URL by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the current user's campaign templates",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
-                ],
-                "summary": "Get URL by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "URL ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "campaigns"
                 ],
+                "summary": "Get campaign templates",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.GetURLResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/common.ErrorResponse"
+                            "$ref": "#/definitions/campaign.GetCampaignTemplatesResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update a URL",
+            "post": {
+                "description": "Save a reusable set of UTM parameters. Links using the template have them added to their destination,\nexcept for parameters the link sets itself or the destination already contains.",
                 "consumes": [
                     "application/json"
                 ],
@@ -394,24 +360,121 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
+                    "campaigns"
                 ],
-                "summary": "Update URL",
+                "summary": "Create campaign template",
+                "parameters": [
+                    {
+                        "description": "Campaign template details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/campaign.CreateCampaignTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/campaign.CampaignTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/campaigns/{id}": {
+            "get": {
+                "description": "Get one of the current user's campaign templates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Get campaign template",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "URL ID",
+                        "description": "Campaign template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/campaign.CampaignTemplateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a campaign template. Changes apply to every link using it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Update campaign template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Campaign template ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "URL update details",
+                        "description": "Campaign template update details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/url.UpdateURLRequest"
+                            "$ref": "#/definitions/campaign.UpdateCampaignTemplateRequest"
                         }
                     }
                 ],
@@ -419,7 +482,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.UpdateURLResponse"
+                            "$ref": "#/definitions/campaign.CampaignTemplateResponse"
                         }
                     },
                     "400": {
@@ -428,30 +491,39 @@ const docTemplate = `{
                             "$ref": "#/definitions/common.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete a URL",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a campaign template. Links using it keep their own UTM parameters.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
+                    "campaigns"
                 ],
-                "summary": "Delete URL",
+                "summary": "Delete campaign template",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "URL ID",
+                        "description": "Campaign template ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -461,7 +533,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.DeleteURLResponse"
+                            "$ref": "#/definitions/campaign.DeleteCampaignTemplateResponse"
                         }
                     },
                     "400": {
@@ -470,6 +542,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -479,9 +557,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/urls/{id}/stats": {
+        "/domains": {
             "get": {
-                "description": "Get statistics for a specific URL",
+                "description": "Get the current user's custom domains",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Get domains",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.GetDomainsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a custom domain to serve short links from. Publish the returned TXT record, then verify the domain\nbefore creating links on it. The domain's DNS must also point at this server.",
                 "consumes": [
                     "application/json"
                 ],
@@ -489,13 +591,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "urls"
+                    "domains"
                 ],
-                "summary": "Get URL statistics",
+                "summary": "Add domain",
+                "parameters": [
+                    {
+                        "description": "Domain details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateDomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/domains/{id}": {
+            "delete": {
+                "description": "Delete a custom domain that no longer has any links",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "domains"
+                ],
+                "summary": "Delete domain",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "URL ID",
+                        "description": "Domain ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -505,7 +656,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/url.URLStatsResponse"
+                            "$ref": "#/definitions/domain.DeleteDomainResponse"
                         }
                     },
                     "400": {
@@ -514,36 +665,63 @@ const docTemplate = `{
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Domain still has links",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/{short_code}": {
-            "get": {
-                "description": "Redirect to the original URL using short code",
+        "/domains/{id}/verify": {
+            "post": {
+                "description": "Check the domain's verification TXT record and mark it verified if the token matches",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "urls"
+                    "domains"
                 ],
-                "summary": "Redirect to original URL",
+                "summary": "Verify domain",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Short code",
-                        "name": "short_code",
+                        "type": "integer",
+                        "description": "Domain ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Redirect to original URL",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/domain.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Verification record missing or wrong",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
                         }
                     },
                     "404": {
@@ -551,163 +729,2621 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Domain verified by another user",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "password123"
-                }
-            }
         },
-        "auth.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-01-01T12:00:00Z"
-                },
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+        "/ping": {
+            "get": {
+                "description": "Ping",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ping"
+                ],
+                "summary": "Ping",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/urls": {
+            "get": {
+                "description": "Get URLs with pagination and filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get URLs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort direction",
+                        "name": "sort_dir",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.GetURLsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new short URL",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Create URL",
+                "parameters": [
+                    {
+                        "description": "URL creation details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.CreateURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/url.CreateURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/bulk": {
+            "post": {
+                "description": "Create many short URLs at once, either as JSON or as a CSV file (text/csv body, or multipart/form-data\nwith the file in the \"file\" field). CSV columns are named like the JSON fields, with utm_source,\nutm_medium, utm_campaign, utm_term and utm_content for UTM parameters; original_url is required.\nEach item is created on its own: the response lists every item's result with the status and error code\ncreating it alone would have returned. 201 means every item was created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Create URLs in bulk",
+                "parameters": [
+                    {
+                        "description": "URLs to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.BulkCreateURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Some items failed",
+                        "schema": {
+                            "$ref": "#/definitions/url.BulkCreateURLResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/url.BulkCreateURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/public": {
+            "post": {
+                "description": "Create a new short URL without authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Create Public URL",
+                "parameters": [
+                    {
+                        "description": "URL creation details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.CreateURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/url.CreateURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}": {
+            "get": {
+                "description": "Get a specific URL by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get URL by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.GetURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a URL",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Update URL",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "URL update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.UpdateURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.UpdateURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a URL",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Delete URL",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.DeleteURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/qr": {
+            "get": {
+                "description": "Render a QR code of the link's full short URL, on its custom domain if it has one.\nThe encoded URL carries a marker so scans are reported as the \"qr\" source in click statistics.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get URL QR code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 2048,
+                        "minimum": 64,
+                        "type": "integer",
+                        "default": 256,
+                        "description": "Width and height in pixels",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "L",
+                            "M",
+                            "Q",
+                            "H"
+                        ],
+                        "type": "string",
+                        "default": "M",
+                        "description": "Error correction level",
+                        "name": "ecc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "000000",
+                        "description": "Foreground colour as RRGGBB",
+                        "name": "fg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "ffffff",
+                        "description": "Background colour as RRGGBB",
+                        "name": "bg",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 16,
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 4,
+                        "description": "Blank border in modules",
+                        "name": "quiet_zone",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/rules": {
+            "get": {
+                "description": "Get a URL's redirect rules in evaluation order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get redirect rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.GetRedirectRulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a rule sending visitors on a given device, operating system or in a given country to a different destination.\nVisitors matching no rule go to the URL's original destination. Country rules need a GeoIP database to be configured.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Create redirect rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Redirect rule details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.CreateRedirectRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/url.RedirectRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/rules/{rule_id}": {
+            "put": {
+                "description": "Update a URL's redirect rule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Update redirect rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Redirect rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Redirect rule update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.UpdateRedirectRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.RedirectRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a URL's redirect rule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Delete redirect rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Redirect rule ID",
+                        "name": "rule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.DeleteRedirectRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/stats": {
+            "get": {
+                "description": "Get statistics for a specific URL, including clicks per variant for URLs rotating between destinations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get URL statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of recent clicks to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Number of values per breakdown",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include crawler and link-preview traffic",
+                        "name": "include_bots",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.URLStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/stats/timeseries": {
+            "get": {
+                "description": "Get clicks for a specific URL aggregated into hourly, daily or weekly buckets",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get URL click time series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (RFC3339), defaults to a window based on interval",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (RFC3339), defaults to now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hour",
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "UTC",
+                        "description": "IANA time zone used to align buckets",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Include crawler and link-preview traffic",
+                        "name": "include_bots",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.URLTimeSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/variants": {
+            "get": {
+                "description": "Get the destinations a URL rotates between",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Get URL variants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.GetURLVariantsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a destination for the URL to rotate between. Each visit picks a variant in proportion to its weight,\nreplacing the original destination; redirect rules still take precedence.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Create URL variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.CreateURLVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/url.URLVariantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/urls/{id}/variants/{variant_id}": {
+            "put": {
+                "description": "Update a URL variant, e.g. set its weight to 0 to pause it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Update URL variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant update details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/url.UpdateURLVariantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.URLVariantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a URL variant; clicks it already served stay in the statistics",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Delete URL variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "URL ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/url.DeleteURLVariantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/settings": {
+            "get": {
+                "description": "Get the current user's link settings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.GetUserSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the current user's link settings, such as the default fallback URL for unavailable links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user settings",
+                "parameters": [
+                    {
+                        "description": "Settings to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateUserSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateUserSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/common.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/{short_code}": {
+            "get": {
+                "description": "Redirect to the original URL using short code, looked up on the custom domain named by the Host header if it is a verified one.\nVisitors matching a device, OS or country rule go to the target of the first matching rule instead.\nURLs with variants redirect to one of them by weight, keeping each visitor on the same variant via a cookie.\nPassword-protected links render an unlock form instead.\nUnavailable links redirect to their fallback URL, or render the server's fallback page for browsers.\nLinks that forward the query string or path append those of the request to the destination, keeping parameters the destination already sets.\nPaths are given after the short code, as in /{short_code}/rest/of/path, and are only accepted by links that forward them.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Redirect to original URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "short_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unlock form for a password-protected link",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "301": {
+                        "description": "Permanent redirect to original URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to original URL, or to the fallback URL of an unavailable link",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "307": {
+                        "description": "Temporary redirect preserving the request method",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "308": {
+                        "description": "Permanent redirect preserving the request method",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Link not active yet, or an automated client opening a click-limited link",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Link expired or reached its click limit",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Submit the password for a protected link and redirect to the original URL",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "urls"
+                ],
+                "summary": "Unlock password-protected URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short code",
+                        "name": "short_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "Redirect to original URL",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unlock form with an error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Link not active yet, or an automated client opening a click-limited link",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Link expired or reached its click limit",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Unlock form with an error message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.CreateReservedCodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "type"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "pricing"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Planned marketing page"
+                },
+                "type": {
+                    "description": "reserved blocks the exact code, blocked every code containing it",
+                    "type": "string",
+                    "enum": [
+                        "reserved",
+                        "blocked"
+                    ],
+                    "example": "reserved"
+                }
+            }
+        },
+        "admin.DeleteReservedCodeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "admin.GetReservedCodesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.ReservedCode"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "admin.ReservedCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "pricing"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Planned marketing page"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "reserved"
+                }
+            }
+        },
+        "admin.ReservedCodeResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/admin.ReservedCode"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "auth.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.UserInfo"
+                }
+            }
+        },
+        "auth.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6..."
+                }
+            }
+        },
+        "auth.RefreshTokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.UserInfo"
+                }
+            }
+        },
+        "auth.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "example": "johndoe"
+                }
+            }
+        },
+        "auth.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.UserInfo"
+                }
+            }
+        },
+        "auth.UserInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "campaign.CampaignTemplate": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Spring newsletter"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "utm": {
+                    "$ref": "#/definitions/url.UTMParams"
+                }
+            }
+        },
+        "campaign.CampaignTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/campaign.CampaignTemplate"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "campaign.CreateCampaignTemplateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Spring newsletter"
+                },
+                "utm": {
+                    "$ref": "#/definitions/url.UTMParams"
+                }
+            }
+        },
+        "campaign.DeleteCampaignTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "campaign.GetCampaignTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/campaign.CampaignTemplate"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "campaign.UpdateCampaignTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Summer newsletter"
+                },
+                "utm": {
+                    "description": "Replaces all UTM parameters",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/url.UTMParams"
+                        }
+                    ]
+                }
+            }
+        },
+        "common.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "common.Pagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "common.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "common.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "validation_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.ValidationError"
+                    }
+                }
+            }
+        },
+        "domain.CreateDomainRequest": {
+            "type": "object",
+            "required": [
+                "hostname"
+            ],
+            "properties": {
+                "hostname": {
+                    "type": "string",
+                    "maxLength": 253,
+                    "example": "go.example.com"
+                }
+            }
+        },
+        "domain.DeleteDomainResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.Domain": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "hostname": {
+                    "type": "string",
+                    "example": "go.example.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "verification_record": {
+                    "$ref": "#/definitions/domain.VerificationRecord"
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "verified_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "domain.DomainResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/domain.Domain"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.GetDomainsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Domain"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.VerificationRecord": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "_myapp-verification.go.example.com"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "TXT"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "3f2a9c4e1b7d8a6f5e4c3b2a1d0e9f8a"
+                }
+            }
+        },
+        "url.BreakdownItem": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "value": {
+                    "type": "string",
+                    "example": "Chrome"
+                }
+            }
+        },
+        "url.BulkCreateURLRequest": {
+            "type": "object",
+            "required": [
+                "urls"
+            ],
+            "properties": {
+                "urls": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/url.CreateURLRequest"
+                    }
+                }
+            }
+        },
+        "url.BulkCreateURLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/url.BulkCreateURLResults"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.BulkCreateURLResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SHORT_CODE_ALREADY_EXISTS"
+                },
+                "data": {
+                    "$ref": "#/definitions/url.URLResponse"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Short code already exists"
+                },
+                "index": {
+                    "description": "Position in the request, or CSV data row counting from 0",
+                    "type": "integer",
+                    "example": 0
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "validation_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.ValidationError"
+                    }
+                }
+            }
+        },
+        "url.BulkCreateURLResults": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BulkCreateURLResult"
+                    }
+                }
+            }
+        },
+        "url.ClickBreakdowns": {
+            "type": "object",
+            "properties": {
+                "browsers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                },
+                "cities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                },
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                },
+                "operating_systems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                },
+                "referrers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.BreakdownItem"
+                    }
+                }
+            }
+        },
+        "url.ClickEvent": {
+            "type": "object",
+            "properties": {
+                "browser": {
+                    "type": "string",
+                    "example": "Chrome"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "San Francisco"
+                },
+                "clicked_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "US"
+                },
+                "device_type": {
+                    "type": "string",
+                    "example": "desktop"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "192.168.1.1"
+                },
+                "is_bot": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "os": {
+                    "type": "string",
+                    "example": "macOS"
+                },
+                "referer": {
+                    "type": "string",
+                    "example": "https://google.com"
+                },
+                "referer_domain": {
+                    "type": "string",
+                    "example": "google.com"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "qr"
+                },
+                "url_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0..."
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "url.ClickQueueMetrics": {
+            "type": "object",
+            "properties": {
+                "dropped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "enqueued": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "flushed": {
+                    "type": "integer",
+                    "example": 1180
+                },
+                "queue_capacity": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "queue_length": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "url.ClickQueueMetricsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/url.ClickQueueMetrics"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.CreateRedirectRuleRequest": {
+            "type": "object",
+            "required": [
+                "target_url",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "position": {
+                    "description": "Defaults to after the existing rules",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "target_url": {
+                    "type": "string",
+                    "example": "https://apps.apple.com/app/id123456789"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "device",
+                        "os",
+                        "country"
+                    ],
+                    "example": "os"
+                },
+                "value": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "iOS"
+                }
+            }
+        },
+        "url.CreateURLRequest": {
+            "type": "object",
+            "required": [
+                "original_url"
+            ],
+            "properties": {
+                "activates_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "campaign_template_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "domain_id": {
+                    "description": "A verified custom domain to serve the link from",
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "fallback_url": {
+                    "type": "string",
+                    "example": "https://example.com/coming-soon"
+                },
+                "forward_path": {
+                    "description": "Append the path after the short code to the destination",
+                    "type": "boolean",
+                    "example": false
+                },
+                "forward_query": {
+                    "description": "Append the short link's query string to the destination",
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_clicks": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "original_url": {
+                    "type": "string",
+                    "example": "https://example.com/very/long/url"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 4,
+                    "example": "s3cret"
+                },
+                "redirect_status": {
+                    "type": "integer",
+                    "enum": [
+                        301,
+                        302,
+                        307,
+                        308
+                    ],
+                    "example": 302
+                },
+                "short_code": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "utm": {
+                    "description": "UTM parameters added to the destination, overriding those of the campaign template",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/url.UTMParams"
+                        }
+                    ]
+                }
+            }
+        },
+        "url.CreateURLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/url.URLResponse"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.CreateURLVariantRequest": {
+            "type": "object",
+            "required": [
+                "target_url"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Landing page B"
+                },
+                "target_url": {
+                    "type": "string",
+                    "example": "https://example.com/landing-b"
+                },
+                "weight": {
+                    "description": "Defaults to 1; 0 pauses the variant",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 50
+                }
+            }
+        },
+        "url.DeleteRedirectRuleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.DeleteURLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.DeleteURLVariantResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.GetRedirectRulesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.RedirectRule"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.GetURLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/url.URLResponse"
                 },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "error": {
+                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.UserInfo"
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "auth.RefreshTokenRequest": {
+        "url.GetURLVariantsResponse": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "a1b2c3d4e5f6..."
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.URLVariant"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "auth.RefreshTokenResponse": {
+        "url.GetURLsResponse": {
             "type": "object",
             "properties": {
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-01-01T12:00:00Z"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.URLResponse"
+                    }
                 },
-                "refresh_token": {
-                    "type": "string",
-                    "example": "a1b2c3d4e5f6..."
+                "error": {
+                    "type": "string"
                 },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "message": {
+                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.UserInfo"
+                "pagination": {
+                    "$ref": "#/definitions/common.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "auth.RegisterRequest": {
+        "url.RedirectRule": {
             "type": "object",
-            "required": [
-                "email",
-                "full_name",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "target_url": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "https://apps.apple.com/app/id123456789"
                 },
-                "full_name": {
+                "type": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "os"
                 },
-                "password": {
+                "value": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                    "example": "iOS"
+                }
+            }
+        },
+        "url.RedirectRuleResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/url.RedirectRule"
                 },
-                "username": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.TimeSeriesPoint": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "timestamp": {
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "example": "johndoe"
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
-        "auth.RegisterResponse": {
+        "url.URLResponse": {
             "type": "object",
             "properties": {
+                "activates_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "bot_click_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "campaign_template_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "click_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "domain_id": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "expires_at": {
                     "type": "string",
-                    "example": "2024-01-01T12:00:00Z"
+                    "example": "2024-12-31T23:59:59Z"
                 },
-                "refresh_token": {
+                "fallback_url": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "https://example.com/coming-soon"
                 },
-                "token": {
+                "forward_path": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "forward_query": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "has_password": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_clicks": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "original_url": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "https://example.com/very/long/url"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.UserInfo"
+                "redirect_rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.RedirectRule"
+                    }
+                },
+                "redirect_status": {
+                    "description": "Omitted when the link follows the server default",
+                    "type": "integer",
+                    "example": 302
+                },
+                "short_code": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "short_url": {
+                    "type": "string",
+                    "example": "https://go.example.com/abc123"
+                },
+                "unique_clicks": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "utm": {
+                    "$ref": "#/definitions/url.UTMParams"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.URLVariant"
+                    }
                 }
             }
         },
-        "auth.UserInfo": {
+        "url.URLStats": {
             "type": "object",
             "properties": {
-                "email": {
+                "activates_at": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "2024-06-01T09:00:00Z"
                 },
-                "full_name": {
+                "bot_click_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "breakdowns": {
+                    "$ref": "#/definitions/url.ClickBreakdowns"
+                },
+                "campaign_template_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "click_count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "created_at": {
                     "type": "string",
-                    "example": "John Doe"
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "domain_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2024-12-31T23:59:59Z"
+                },
+                "fallback_url": {
+                    "type": "string",
+                    "example": "https://example.com/coming-soon"
+                },
+                "forward_path": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "forward_query": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "has_password": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_clicks": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "original_url": {
+                    "type": "string",
+                    "example": "https://example.com/very/long/url"
+                },
+                "recent_clicks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.ClickEvent"
+                    }
+                },
+                "redirect_rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.RedirectRule"
+                    }
+                },
+                "redirect_status": {
+                    "description": "Omitted when the link follows the server default",
+                    "type": "integer",
+                    "example": 302
+                },
+                "short_code": {
+                    "type": "string",
+                    "example": "abc123"
+                },
+                "short_url": {
+                    "type": "string",
+                    "example": "https://go.example.com/abc123"
+                },
+                "unique_clicks": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "utm": {
+                    "$ref": "#/definitions/url.UTMParams"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "johndoe"
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.VariantStats"
+                    }
                 }
             }
         },
-        "common.ErrorResponse": {
+        "url.URLStatsResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/url.URLStats"
                 },
-                "data": {},
                 "error": {
                     "type": "string"
                 },
@@ -719,44 +3355,47 @@ const docTemplate = `{
                 }
             }
         },
-        "common.Pagination": {
+        "url.URLTimeSeries": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "page": {
-                    "type": "integer"
+                "interval": {
+                    "type": "string",
+                    "example": "day"
                 },
-                "total": {
-                    "type": "integer"
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/url.TimeSeriesPoint"
+                    }
                 },
-                "total_pages": {
-                    "type": "integer"
-                }
-            }
-        },
-        "common.ValidationError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "type": "string"
+                "timezone": {
+                    "type": "string",
+                    "example": "UTC"
                 },
-                "message": {
-                    "type": "string"
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-31T00:00:00Z"
                 },
-                "value": {
-                    "type": "string"
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "url_id": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "common.ValidationErrorResponse": {
+        "url.URLTimeSeriesResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/url.URLTimeSeries"
                 },
-                "data": {},
                 "error": {
                     "type": "string"
                 },
@@ -765,71 +3404,35 @@ const docTemplate = `{
                 },
                 "success": {
                     "type": "boolean"
-                },
-                "validation_errors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/common.ValidationError"
-                    }
                 }
             }
         },
-        "url.ClickEvent": {
+        "url.URLVariant": {
             "type": "object",
             "properties": {
-                "clicked_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
-                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "ip_address": {
+                "label": {
                     "type": "string",
-                    "example": "192.168.1.1"
+                    "example": "Landing page B"
                 },
-                "referer": {
+                "target_url": {
                     "type": "string",
-                    "example": "https://google.com"
+                    "example": "https://example.com/landing-b"
                 },
-                "url_id": {
+                "weight": {
                     "type": "integer",
-                    "example": 1
-                },
-                "user_agent": {
-                    "type": "string",
-                    "example": "Mozilla/5.0..."
-                }
-            }
-        },
-        "url.CreateURLRequest": {
-            "type": "object",
-            "required": [
-                "original_url"
-            ],
-            "properties": {
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-12-31T23:59:59Z"
-                },
-                "original_url": {
-                    "type": "string",
-                    "example": "https://example.com/very/long/url"
-                },
-                "short_code": {
-                    "type": "string",
-                    "maxLength": 8,
-                    "minLength": 3,
-                    "example": "abc123"
+                    "example": 50
                 }
             }
         },
-        "url.CreateURLResponse": {
+        "url.URLVariantResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/url.URLResponse"
+                    "$ref": "#/definitions/url.URLVariant"
                 },
                 "error": {
                     "type": "string"
@@ -842,154 +3445,202 @@ const docTemplate = `{
                 }
             }
         },
-        "url.DeleteURLResponse": {
+        "url.UTMParams": {
             "type": "object",
             "properties": {
-                "data": {},
-                "error": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
+                "campaign": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "spring_sale"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "url.GetURLResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/url.URLResponse"
+                "content": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "header_link"
                 },
-                "error": {
-                    "type": "string"
+                "medium": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "email"
                 },
-                "message": {
-                    "type": "string"
+                "source": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "newsletter"
                 },
-                "success": {
-                    "type": "boolean"
+                "term": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "running+shoes"
                 }
             }
         },
-        "url.GetURLsResponse": {
+        "url.UpdateRedirectRuleRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/url.URLResponse"
-                    }
-                },
-                "error": {
-                    "type": "string"
+                "position": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
                 },
-                "message": {
-                    "type": "string"
+                "target_url": {
+                    "type": "string",
+                    "example": "https://example.com/tablet"
                 },
-                "pagination": {
-                    "$ref": "#/definitions/common.Pagination"
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "device",
+                        "os",
+                        "country"
+                    ],
+                    "example": "device"
                 },
-                "success": {
-                    "type": "boolean"
+                "value": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "tablet"
                 }
             }
         },
-        "url.URLResponse": {
+        "url.UpdateURLRequest": {
             "type": "object",
             "properties": {
-                "click_count": {
-                    "type": "integer",
-                    "example": 42
-                },
-                "created_at": {
+                "activates_at": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "campaign_template_id": {
+                    "description": "0 removes the template",
+                    "type": "integer",
+                    "example": 1
                 },
                 "expires_at": {
                     "type": "string",
                     "example": "2024-12-31T23:59:59Z"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "fallback_url": {
+                    "description": "An empty string removes the fallback",
+                    "type": "string",
+                    "example": "https://example.com/coming-soon"
+                },
+                "forward_path": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "forward_query": {
+                    "type": "boolean",
+                    "example": false
                 },
                 "is_active": {
                     "type": "boolean",
                     "example": true
                 },
-                "original_url": {
-                    "type": "string",
-                    "example": "https://example.com/very/long/url"
+                "max_clicks": {
+                    "description": "0 removes the limit",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
                 },
-                "short_code": {
+                "original_url": {
                     "type": "string",
-                    "example": "abc123"
+                    "example": "https://example.com/updated/url"
                 },
-                "updated_at": {
+                "password": {
+                    "description": "An empty string removes the protection",
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "maxLength": 72,
+                    "example": "s3cret"
                 },
-                "user_id": {
+                "redirect_status": {
+                    "description": "0 follows the server default",
                     "type": "integer",
-                    "example": 1
+                    "enum": [
+                        0,
+                        301,
+                        302,
+                        307,
+                        308
+                    ],
+                    "example": 301
+                },
+                "utm": {
+                    "description": "Replaces all UTM parameters",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/url.UTMParams"
+                        }
+                    ]
                 }
             }
         },
-        "url.URLStats": {
+        "url.UpdateURLResponse": {
             "type": "object",
             "properties": {
-                "click_count": {
-                    "type": "integer",
-                    "example": 42
+                "data": {
+                    "$ref": "#/definitions/url.URLResponse"
                 },
-                "created_at": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "url.UpdateURLVariantRequest": {
+            "type": "object",
+            "properties": {
+                "label": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "maxLength": 50,
+                    "example": "Landing page C"
                 },
-                "expires_at": {
+                "target_url": {
                     "type": "string",
-                    "example": "2024-12-31T23:59:59Z"
+                    "example": "https://example.com/landing-c"
+                },
+                "weight": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 25
+                }
+            }
+        },
+        "url.VariantStats": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer",
+                    "example": 120
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "original_url": {
-                    "type": "string",
-                    "example": "https://example.com/very/long/url"
-                },
-                "recent_clicks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/url.ClickEvent"
-                    }
-                },
-                "short_code": {
+                "label": {
                     "type": "string",
-                    "example": "abc123"
+                    "example": "Landing page B"
                 },
-                "updated_at": {
+                "target_url": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "https://example.com/landing-b"
                 },
-                "user_id": {
+                "weight": {
                     "type": "integer",
-                    "example": 1
+                    "example": 50
                 }
             }
         },
-        "url.URLStatsResponse": {
+        "user.GetUserSettingsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/url.URLStats"
+                    "$ref": "#/definitions/user.UserSettings"
                 },
                 "error": {
                     "type": "string"
@@ -1002,28 +3653,21 @@ const docTemplate = `{
                 }
             }
         },
-        "url.UpdateURLRequest": {
+        "user.UpdateUserSettingsRequest": {
             "type": "object",
             "properties": {
-                "expires_at": {
-                    "type": "string",
-                    "example": "2024-12-31T23:59:59Z"
-                },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "original_url": {
+                "default_fallback_url": {
+                    "description": "An empty string removes the fallback",
                     "type": "string",
-                    "example": "https://example.com/updated/url"
+                    "example": "https://example.com/link-unavailable"
                 }
             }
         },
-        "url.UpdateURLResponse": {
+        "user.UpdateUserSettingsResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/url.URLResponse"
+                    "$ref": "#/definitions/user.UserSettings"
                 },
                 "error": {
                     "type": "string"
@@ -1035,6 +3679,15 @@ const docTemplate = `{
                     "type": "boolean"
                 }
             }
+        },
+        "user.UserSettings": {
+            "type": "object",
+            "properties": {
+                "default_fallback_url": {
+                    "type": "string",
+                    "example": "https://example.com/link-unavailable"
+                }
+            }
         }
     }
 }`
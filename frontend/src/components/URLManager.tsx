@@ -368,13 +368,13 @@ export function URLManager() {
 
                     <HStack>
                       <Text fontFamily="mono" fontSize="sm">
-                        {getShortURL(url.short_code)}
+                        {url.short_url || getShortURL(url.short_code)}
                       </Text>
                       <IconButton
                         size="xs"
                         variant="ghost"
                         onClick={() =>
-                          copyToClipboard(getShortURL(url.short_code))
+                          copyToClipboard(url.short_url || getShortURL(url.short_code))
                         }
                       >
                         📋
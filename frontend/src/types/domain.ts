@@ -0,0 +1,31 @@
+export interface VerificationRecord {
+  type: "TXT";
+  name: string;
+  value: string;
+}
+
+export interface Domain {
+  id: number;
+  hostname: string;
+  verified: boolean;
+  verified_at?: string;
+  verification_record: VerificationRecord;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateDomainRequest {
+  hostname: string;
+}
+
+export interface DomainResponse {
+  success: boolean;
+  message: string;
+  data: Domain;
+}
+
+export interface GetDomainsResponse {
+  success: boolean;
+  message: string;
+  data: Domain[];
+}
@@ -2,10 +2,56 @@ export interface URL {
   id: number;
   original_url: string;
   short_code: string;
+  short_url?: string;
+  domain_id?: number;
   user_id?: number;
+  activates_at?: string;
   expires_at?: string;
   click_count: number;
+  unique_clicks: number;
+  bot_click_count: number;
   is_active: boolean;
+  redirect_status?: 301 | 302 | 307 | 308; // Omitted when the link follows the server default
+  has_password: boolean;
+  max_clicks?: number;
+  fallback_url?: string;
+  forward_query: boolean;
+  forward_path: boolean;
+  redirect_rules: RedirectRule[];
+  variants: URLVariant[];
+  utm: UTMParams;
+  campaign_template_id?: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface RedirectRule {
+  id: number;
+  position: number;
+  type: "device" | "os" | "country";
+  value: string;
+  target_url: string;
+}
+
+export interface URLVariant {
+  id: number;
+  label: string;
+  target_url: string;
+  weight: number;
+}
+
+export interface UTMParams {
+  source?: string;
+  medium?: string;
+  campaign?: string;
+  term?: string;
+  content?: string;
+}
+
+export interface CampaignTemplate {
+  id: number;
+  name: string;
+  utm: UTMParams;
   created_at: string;
   updated_at: string;
 }
@@ -13,13 +59,32 @@ export interface URL {
 export interface CreateURLRequest {
   original_url: string;
   short_code?: string;
+  domain_id?: number;
+  activates_at?: string;
   expires_at?: string;
+  redirect_status?: 301 | 302 | 307 | 308;
+  password?: string;
+  max_clicks?: number;
+  fallback_url?: string;
+  forward_query?: boolean;
+  forward_path?: boolean;
+  utm?: UTMParams;
+  campaign_template_id?: number;
 }
 
 export interface UpdateURLRequest {
   original_url?: string;
+  activates_at?: string;
   expires_at?: string;
   is_active?: boolean;
+  redirect_status?: 0 | 301 | 302 | 307 | 308; // 0 follows the server default
+  password?: string;
+  max_clicks?: number;
+  fallback_url?: string;
+  forward_query?: boolean;
+  forward_path?: boolean;
+  utm?: UTMParams;
+  campaign_template_id?: number;
 }
 
 export interface URLResponse {
@@ -43,6 +108,27 @@ export interface URLsResponse {
 export interface URLStats {
   url_response: URL;
   recent_clicks?: ClickEvent[];
+  breakdowns: ClickBreakdowns;
+  variants?: VariantStats[];
+}
+
+export interface VariantStats extends URLVariant {
+  clicks: number;
+}
+
+export interface BreakdownItem {
+  value: string;
+  clicks: number;
+}
+
+export interface ClickBreakdowns {
+  browsers: BreakdownItem[];
+  operating_systems: BreakdownItem[];
+  devices: BreakdownItem[];
+  referrers: BreakdownItem[];
+  countries: BreakdownItem[];
+  cities: BreakdownItem[];
+  sources: BreakdownItem[];
 }
 
 export interface ClickEvent {
@@ -51,6 +137,15 @@ export interface ClickEvent {
   ip_address: string;
   user_agent: string;
   referer?: string;
+  browser: string;
+  os: string;
+  device_type: string;
+  referer_domain?: string;
+  is_bot: boolean;
+  country?: string;
+  city?: string;
+  variant_id?: number;
+  source?: string;
   clicked_at: string;
 }
 
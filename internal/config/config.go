@@ -3,15 +3,23 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/tinwritescode/myapp/pkg/logger"
+	"github.com/tinwritescode/myapp/pkg/utils"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	JWT      JWTConfig
+	Database  DatabaseConfig
+	Server    ServerConfig
+	JWT       JWTConfig
+	Analytics AnalyticsConfig
+	Cache     CacheConfig
+	Redirect  RedirectConfig
+	ShortCode ShortCodeConfig
 }
 
 type DatabaseConfig struct {
@@ -24,17 +32,87 @@ type DatabaseConfig struct {
 
 type ServerConfig struct {
 	Port string
+	// Environment is "production" in deployments and "development" otherwise
+	Environment string
+	// BaseURL is the scheme and host short links on the server's own domain are served from.
+	// Empty leaves short URLs out of responses, and is refused in production.
+	BaseURL string
+	// AdminUserIDs are made admins at startup. Accounts are named by ID rather than email, as
+	// registering doesn't prove ownership of an email address.
+	AdminUserIDs []uint
+	// BulkCreateLimit is the most URLs one bulk creation request may contain
+	BulkCreateLimit int
+	// TrustedProxies may set X-Forwarded-For; with none, the client IP is the connection's address
+	TrustedProxies []string
+	// TrustedPlatform names a header set by the hosting platform holding the client IP, e.g. Fly-Client-IP
+	TrustedPlatform string
 }
 
 type JWTConfig struct {
 	Secret string
 }
 
+type AnalyticsConfig struct {
+	// GeoIPDatabasePath points at a MaxMind-format (MMDB) database; empty disables GeoIP
+	GeoIPDatabasePath string
+	// VisitorSalt is mixed into unique visitor hashes so they cannot be reversed to IPs
+	VisitorSalt string
+	// Click events are queued in memory and written to the database in batches
+	ClickQueueSize     int
+	ClickBatchSize     int
+	ClickFlushInterval time.Duration
+}
+
+type CacheConfig struct {
+	// URLCacheSize is the number of short codes kept in memory; 0 disables the cache. Only safe
+	// with a single instance, as changes to a link only clear the cache of the instance making them.
+	URLCacheSize int
+	URLCacheTTL  time.Duration
+	// Misses for unknown short codes are remembered so scanners don't hit the database. Only
+	// safe with a single instance, as other instances keep answering 404 for new links until
+	// the misses expire.
+	NegativeCacheSize int
+	NegativeCacheTTL  time.Duration
+	// ShortCodeFilterEnabled turns on the bloom filter of existing short codes. Only safe with
+	// a single instance, as other instances 404 new links until their next rebuild.
+	ShortCodeFilterEnabled         bool
+	ShortCodeFilterRebuildInterval time.Duration
+}
+
+type RedirectConfig struct {
+	// DefaultStatus is used for links that don't choose a status: 301, 302, 307 or 308
+	DefaultStatus int
+	// Wrong passwords for a protected link are limited per client
+	PasswordMaxAttempts int
+	PasswordLockout     time.Duration
+	// FallbackPagePath is an optional HTML template shown to browsers visiting unavailable links
+	FallbackPagePath string
+}
+
+type ShortCodeConfig struct {
+	// Strategy generates codes for links without a custom one: "random" or "sequence"
+	Strategy string
+	// Length of generated codes; custom codes must be between MinLength and MaxLength
+	Length    int
+	MinLength int
+	MaxLength int
+	// Alphabet of generated codes: "alphanumeric", "unambiguous" or the characters to use
+	Alphabet string
+	// Reserved codes can't be used as custom codes
+	Reserved []string
+	// Codes containing a blocklisted word are rejected
+	Blocklist []string
+	// CaseInsensitive stores codes lowercased and resolves them ignoring case
+	CaseInsensitive bool
+}
+
 func Load() *Config {
 	if err := godotenv.Load(); err != nil {
 		logger.Info("No .env file found, using environment variables or defaults")
 	}
 
+	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
+
 	return &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -44,10 +122,47 @@ func Load() *Config {
 			DBName:   getEnv("DB_NAME", "myapp"),
 		},
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
+			Port:            getEnv("SERVER_PORT", "8080"),
+			Environment:     getEnv("ENV", "development"),
+			BaseURL:         getEnv("BASE_URL", ""),
+			AdminUserIDs:    getEnvIDList("ADMIN_USER_IDS"),
+			BulkCreateLimit: getEnvInt("BULK_CREATE_LIMIT", 500),
+			TrustedProxies:  getEnvList("TRUSTED_PROXIES", nil),
+			TrustedPlatform: getEnv("TRUSTED_PLATFORM", ""),
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your-secret-key"),
+			Secret: jwtSecret,
+		},
+		Analytics: AnalyticsConfig{
+			GeoIPDatabasePath:  getEnv("GEOIP_DATABASE_PATH", ""),
+			VisitorSalt:        getEnv("VISITOR_HASH_SALT", jwtSecret),
+			ClickQueueSize:     getEnvInt("CLICK_QUEUE_SIZE", 10000),
+			ClickBatchSize:     getEnvInt("CLICK_BATCH_SIZE", 500),
+			ClickFlushInterval: getEnvDuration("CLICK_FLUSH_INTERVAL", time.Second),
+		},
+		Cache: CacheConfig{
+			URLCacheSize:                   getEnvInt("URL_CACHE_SIZE", 0),
+			URLCacheTTL:                    getEnvDuration("URL_CACHE_TTL", 5*time.Minute),
+			NegativeCacheSize:              getEnvInt("NEGATIVE_CACHE_SIZE", 0),
+			NegativeCacheTTL:               getEnvDuration("NEGATIVE_CACHE_TTL", time.Minute),
+			ShortCodeFilterEnabled:         getEnvBool("SHORT_CODE_FILTER_ENABLED", false),
+			ShortCodeFilterRebuildInterval: getEnvDuration("SHORT_CODE_FILTER_REBUILD_INTERVAL", 10*time.Minute),
+		},
+		Redirect: RedirectConfig{
+			DefaultStatus:       getEnvInt("REDIRECT_DEFAULT_STATUS", 302),
+			PasswordMaxAttempts: getEnvInt("LINK_PASSWORD_MAX_ATTEMPTS", 5),
+			PasswordLockout:     getEnvDuration("LINK_PASSWORD_LOCKOUT", 15*time.Minute),
+			FallbackPagePath:    getEnv("FALLBACK_PAGE_PATH", ""),
+		},
+		ShortCode: ShortCodeConfig{
+			Strategy:        getEnv("SHORT_CODE_STRATEGY", "random"),
+			Length:          getEnvInt("SHORT_CODE_LENGTH", 6),
+			MinLength:       getEnvInt("SHORT_CODE_MIN_LENGTH", 3),
+			MaxLength:       getEnvInt("SHORT_CODE_MAX_LENGTH", 8),
+			Alphabet:        getEnv("SHORT_CODE_ALPHABET", "alphanumeric"),
+			Reserved:        getEnvList("SHORT_CODE_RESERVED", utils.DefaultReservedShortCodes),
+			Blocklist:       getEnvList("SHORT_CODE_BLOCKLIST", utils.DefaultShortCodeBlocklist),
+			CaseInsensitive: getEnvBool("SHORT_CODE_CASE_INSENSITIVE", false),
 		},
 	}
 }
@@ -59,6 +174,66 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+		logger.Warnf("Invalid integer for %s, using default %d", key, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+		logger.Warnf("Invalid boolean for %s, using default %t", key, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+		logger.Warnf("Invalid duration for %s, using default %s", key, defaultValue)
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma-separated list, dropping blank entries
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvIDList reads a comma-separated list of IDs, skipping entries that aren't IDs
+func getEnvIDList(key string) []uint {
+	var ids []uint
+	for _, item := range getEnvList(key, nil) {
+		id, err := strconv.ParseUint(item, 10, 32)
+		if err != nil || id == 0 {
+			logger.Warnf("Invalid ID %q in %s, skipping it", item, key)
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
 // GetDatabaseDSN returns the database connection string
 // It prioritizes DATABASE_URL (used by Fly.io and Neon.db) over individual variables
 func (c *Config) GetDatabaseDSN() string {
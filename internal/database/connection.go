@@ -14,7 +14,8 @@ func ConnectDB(dsn string) error {
 	var err error
 
 	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+		Logger:         gormLogger.Default.LogMode(gormLogger.Info),
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/tinwritescode/myapp/internal/dto/common"
@@ -20,3 +21,34 @@ func AutoMigrate(models ...interface{}) error {
 	log.Println("Database migrations completed successfully")
 	return nil
 }
+
+// DropIndex removes an index a model no longer declares, as AutoMigrate only ever adds them
+func DropIndex(model interface{}, name string) error {
+	if DB == nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "database connection not initialized", nil)
+	}
+
+	migrator := DB.Migrator()
+	if !migrator.HasIndex(model, name) {
+		return nil
+	}
+
+	if err := migrator.DropIndex(model, name); err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "failed to drop index "+name, err)
+	}
+
+	log.Printf("Dropped index %s", name)
+	return nil
+}
+
+// CreateUniqueIndex creates a unique index on expressions, which model tags can't declare
+func CreateUniqueIndex(name, table, expressions string) error {
+	if DB == nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "database connection not initialized", nil)
+	}
+
+	if err := DB.Exec(fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (%s)", name, table, expressions)).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "failed to create index "+name, err)
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package admin
+
+// CreateReservedCodeRequest represents the request to reserve a short code or block a word
+type CreateReservedCodeRequest struct {
+	Code   string `json:"code" binding:"required,alphanum,max=50" example:"pricing"`
+	Type   string `json:"type" binding:"required,oneof=reserved blocked" example:"reserved"` // reserved blocks the exact code, blocked every code containing it
+	Reason string `json:"reason,omitempty" binding:"max=255" example:"Planned marketing page"`
+}
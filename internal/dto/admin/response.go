@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/common"
+)
+
+// ReservedCode represents a short code or word taken out of use by an admin
+type ReservedCode struct {
+	ID        uint      `json:"id" example:"1"`
+	Code      string    `json:"code" example:"pricing"`
+	Type      string    `json:"type" example:"reserved"`
+	Reason    string    `json:"reason,omitempty" example:"Planned marketing page"`
+	CreatedBy uint      `json:"created_by" example:"1"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// ReservedCodeResponse represents the response for a single reserved code
+type ReservedCodeResponse struct {
+	common.BaseResponse
+	Data ReservedCode `json:"data"`
+}
+
+// GetReservedCodesResponse represents the response for all reserved codes
+type GetReservedCodesResponse struct {
+	common.BaseResponse
+	Data []ReservedCode `json:"data"`
+}
+
+// DeleteReservedCodeResponse represents the response when deleting a reserved code
+type DeleteReservedCodeResponse struct {
+	common.BaseResponse
+}
@@ -0,0 +1,15 @@
+package campaign
+
+import "github.com/tinwritescode/myapp/internal/dto/url"
+
+// CreateCampaignTemplateRequest represents the request to create a campaign template
+type CreateCampaignTemplateRequest struct {
+	Name string        `json:"name" binding:"required,max=100" example:"Spring newsletter"`
+	UTM  url.UTMParams `json:"utm"`
+}
+
+// UpdateCampaignTemplateRequest represents the request to update a campaign template
+type UpdateCampaignTemplateRequest struct {
+	Name *string        `json:"name,omitempty" binding:"omitempty,min=1,max=100" example:"Summer newsletter"`
+	UTM  *url.UTMParams `json:"utm,omitempty"` // Replaces all UTM parameters
+}
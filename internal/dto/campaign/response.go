@@ -0,0 +1,34 @@
+package campaign
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+)
+
+// CampaignTemplate represents a reusable set of UTM parameters
+type CampaignTemplate struct {
+	ID        uint          `json:"id" example:"1"`
+	Name      string        `json:"name" example:"Spring newsletter"`
+	UTM       url.UTMParams `json:"utm"`
+	CreatedAt time.Time     `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt time.Time     `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// CampaignTemplateResponse represents the response for a single campaign template
+type CampaignTemplateResponse struct {
+	common.BaseResponse
+	Data CampaignTemplate `json:"data"`
+}
+
+// GetCampaignTemplatesResponse represents the response for the user's campaign templates
+type GetCampaignTemplatesResponse struct {
+	common.BaseResponse
+	Data []CampaignTemplate `json:"data"`
+}
+
+// DeleteCampaignTemplateResponse represents the response when deleting a campaign template
+type DeleteCampaignTemplateResponse struct {
+	common.BaseResponse
+}
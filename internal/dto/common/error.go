@@ -22,6 +22,7 @@ const (
 	SHORT_CODE_ALREADY_EXISTS
 	URL_NOT_FOUND
 	URL_EXPIRED
+	URL_NOT_YET_ACTIVE
 )
 
 // String returns the string representation of the error code
@@ -59,6 +60,8 @@ func (e ERROR_CODE) String() string {
 		return "URL_NOT_FOUND"
 	case URL_EXPIRED:
 		return "URL_EXPIRED"
+	case URL_NOT_YET_ACTIVE:
+		return "URL_NOT_YET_ACTIVE"
 	default:
 		return "UNKNOWN_ERROR"
 	}
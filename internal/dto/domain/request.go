@@ -0,0 +1,6 @@
+package domain
+
+// CreateDomainRequest represents the request to add a custom domain
+type CreateDomainRequest struct {
+	Hostname string `json:"hostname" binding:"required,fqdn,max=253" example:"go.example.com"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/common"
+)
+
+// Domain represents a custom domain short links can be served from
+type Domain struct {
+	ID                 uint               `json:"id" example:"1"`
+	Hostname           string             `json:"hostname" example:"go.example.com"`
+	Verified           bool               `json:"verified" example:"false"`
+	VerifiedAt         *time.Time         `json:"verified_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	VerificationRecord VerificationRecord `json:"verification_record"`
+	CreatedAt          time.Time          `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt          time.Time          `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// VerificationRecord is the DNS record proving control of a domain
+type VerificationRecord struct {
+	Type  string `json:"type" example:"TXT"`
+	Name  string `json:"name" example:"_myapp-verification.go.example.com"`
+	Value string `json:"value" example:"3f2a9c4e1b7d8a6f5e4c3b2a1d0e9f8a"`
+}
+
+// DomainResponse represents the response for a single domain
+type DomainResponse struct {
+	common.BaseResponse
+	Data Domain `json:"data"`
+}
+
+// GetDomainsResponse represents the response for the user's domains
+type GetDomainsResponse struct {
+	common.BaseResponse
+	Data []Domain `json:"data"`
+}
+
+// DeleteDomainResponse represents the response when deleting a domain
+type DeleteDomainResponse struct {
+	common.BaseResponse
+}
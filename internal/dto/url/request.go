@@ -4,9 +4,36 @@ import "time"
 
 // CreateURLRequest represents the request to create a new URL
 type CreateURLRequest struct {
-	OriginalURL string     `json:"original_url" binding:"required,url" example:"https://example.com/very/long/url"`
-	ShortCode   *string    `json:"short_code,omitempty" binding:"omitempty,alphanum,min=3,max=8" example:"abc123"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	OriginalURL    string     `json:"original_url" binding:"required,url" example:"https://example.com/very/long/url"`
+	ShortCode      *string    `json:"short_code,omitempty" binding:"omitempty,alphanum" example:"abc123"`
+	DomainID       *uint      `json:"domain_id,omitempty" example:"1"` // A verified custom domain to serve the link from
+	ActivatesAt    *time.Time `json:"activates_at,omitempty" example:"2024-06-01T09:00:00Z"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	RedirectStatus *int       `json:"redirect_status,omitempty" binding:"omitempty,oneof=301 302 307 308" example:"302"`
+	Password       *string    `json:"password,omitempty" binding:"omitempty,min=4,max=72" example:"s3cret"`
+	MaxClicks      *int64     `json:"max_clicks,omitempty" binding:"omitempty,min=1" example:"1"`
+	FallbackURL    *string    `json:"fallback_url,omitempty" binding:"omitempty,url" example:"https://example.com/coming-soon"`
+	ForwardQuery   *bool      `json:"forward_query,omitempty" example:"false"` // Append the short link's query string to the destination
+	ForwardPath    *bool      `json:"forward_path,omitempty" example:"false"`  // Append the path after the short code to the destination
+
+	// UTM parameters added to the destination, overriding those of the campaign template
+	UTM                *UTMParams `json:"utm,omitempty"`
+	CampaignTemplateID *uint      `json:"campaign_template_id,omitempty" example:"1"`
+}
+
+// BulkCreateURLRequest represents the request to create many URLs at once. Each item is
+// validated and created on its own.
+type BulkCreateURLRequest struct {
+	URLs []CreateURLRequest `json:"urls" binding:"required,min=1"`
+}
+
+// UTMParams represents the campaign tracking parameters added to a link's destination
+type UTMParams struct {
+	Source   string `json:"source,omitempty" binding:"max=100" example:"newsletter"`
+	Medium   string `json:"medium,omitempty" binding:"max=100" example:"email"`
+	Campaign string `json:"campaign,omitempty" binding:"max=100" example:"spring_sale"`
+	Term     string `json:"term,omitempty" binding:"max=100" example:"running+shoes"`
+	Content  string `json:"content,omitempty" binding:"max=100" example:"header_link"`
 }
 
 // GetURLsRequest represents the request to get URLs with pagination and filtering
@@ -15,15 +42,86 @@ type GetURLsRequest struct {
 	Limit    int     `form:"limit" binding:"min=1,max=100" example:"10"`
 	Search   *string `form:"search" example:"example"`
 	IsActive *bool   `form:"is_active" example:"true"`
-	SortBy   string  `form:"sort_by" binding:"omitempty,oneof=created_at updated_at click_count" example:"created_at"`
+	SortBy   string  `form:"sort_by" binding:"omitempty,oneof=created_at updated_at click_count unique_clicks" example:"created_at"`
 	SortDir  string  `form:"sort_dir" binding:"omitempty,oneof=asc desc" example:"desc"`
 }
 
 // UpdateURLRequest represents the request to update a URL
 type UpdateURLRequest struct {
-	OriginalURL *string    `json:"original_url,omitempty" binding:"omitempty,url" example:"https://example.com/updated/url"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
-	IsActive    *bool      `json:"is_active,omitempty" example:"true"`
+	OriginalURL    *string    `json:"original_url,omitempty" binding:"omitempty,url" example:"https://example.com/updated/url"`
+	ActivatesAt    *time.Time `json:"activates_at,omitempty" example:"2024-06-01T09:00:00Z"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	IsActive       *bool      `json:"is_active,omitempty" example:"true"`
+	RedirectStatus *int       `json:"redirect_status,omitempty" binding:"omitempty,oneof=0 301 302 307 308" example:"301"`            // 0 follows the server default
+	Password       *string    `json:"password,omitempty" binding:"omitempty,len=0|min=4,max=72" example:"s3cret"`                     // An empty string removes the protection
+	MaxClicks      *int64     `json:"max_clicks,omitempty" binding:"omitempty,min=0" example:"10"`                                    // 0 removes the limit
+	FallbackURL    *string    `json:"fallback_url,omitempty" binding:"omitempty,len=0|url" example:"https://example.com/coming-soon"` // An empty string removes the fallback
+	ForwardQuery   *bool      `json:"forward_query,omitempty" example:"false"`
+	ForwardPath    *bool      `json:"forward_path,omitempty" example:"false"`
+
+	UTM                *UTMParams `json:"utm,omitempty"`                              // Replaces all UTM parameters
+	CampaignTemplateID *uint      `json:"campaign_template_id,omitempty" example:"1"` // 0 removes the template
+}
+
+// CreateRedirectRuleRequest represents the request to add a redirect rule to a URL
+type CreateRedirectRuleRequest struct {
+	Type      string `json:"type" binding:"required,oneof=device os country" example:"os"`
+	Value     string `json:"value" binding:"required,max=50" example:"iOS"`
+	TargetURL string `json:"target_url" binding:"required,url" example:"https://apps.apple.com/app/id123456789"`
+	Position  *int   `json:"position,omitempty" binding:"omitempty,min=0" example:"0"` // Defaults to after the existing rules
+}
+
+// UpdateRedirectRuleRequest represents the request to update a redirect rule
+type UpdateRedirectRuleRequest struct {
+	Type      *string `json:"type,omitempty" binding:"omitempty,oneof=device os country" example:"device"`
+	Value     *string `json:"value,omitempty" binding:"omitempty,max=50" example:"tablet"`
+	TargetURL *string `json:"target_url,omitempty" binding:"omitempty,url" example:"https://example.com/tablet"`
+	Position  *int    `json:"position,omitempty" binding:"omitempty,min=0" example:"1"`
+}
+
+// CreateURLVariantRequest represents the request to add a destination variant to a URL
+type CreateURLVariantRequest struct {
+	TargetURL string  `json:"target_url" binding:"required,url" example:"https://example.com/landing-b"`
+	Weight    *int    `json:"weight,omitempty" binding:"omitempty,min=0,max=1000" example:"50"` // Defaults to 1; 0 pauses the variant
+	Label     *string `json:"label,omitempty" binding:"omitempty,max=50" example:"Landing page B"`
+}
+
+// UpdateURLVariantRequest represents the request to update a URL variant
+type UpdateURLVariantRequest struct {
+	TargetURL *string `json:"target_url,omitempty" binding:"omitempty,url" example:"https://example.com/landing-c"`
+	Weight    *int    `json:"weight,omitempty" binding:"omitempty,min=0,max=1000" example:"25"`
+	Label     *string `json:"label,omitempty" binding:"omitempty,max=50" example:"Landing page C"`
+}
+
+// GetURLStatsRequest represents query parameters for URL statistics
+type GetURLStatsRequest struct {
+	Limit       int  `form:"limit,default=10" binding:"min=1,max=100" example:"10"`
+	Top         int  `form:"top,default=5" binding:"min=1,max=50" example:"5"`
+	IncludeBots bool `form:"include_bots" example:"false"`
+}
+
+// GetQRCodeRequest represents query parameters for a URL's QR code
+type GetQRCodeRequest struct {
+	Format     string `form:"format,default=png" binding:"oneof=png svg" example:"png"`
+	Size       int    `form:"size,default=256" binding:"min=64,max=2048" example:"256"`       // Width and height in pixels
+	ECC        string `form:"ecc,default=M" binding:"oneof=L M Q H" example:"M"`              // Error correction level
+	Foreground string `form:"fg,default=000000" binding:"len=6,hexadecimal" example:"000000"` // RRGGBB
+	Background string `form:"bg,default=ffffff" binding:"len=6,hexadecimal" example:"ffffff"` // RRGGBB
+	QuietZone  int    `form:"quiet_zone,default=4" binding:"min=0,max=16" example:"4"`        // Border width in modules
+}
+
+// GetURLTimeSeriesRequest represents query parameters for the click time series
+type GetURLTimeSeriesRequest struct {
+	From        *time.Time `form:"from" example:"2024-01-01T00:00:00Z"`
+	To          *time.Time `form:"to" example:"2024-01-31T00:00:00Z"`
+	Interval    string     `form:"interval,default=day" binding:"oneof=hour day week" example:"day"`
+	Timezone    string     `form:"timezone,default=UTC" binding:"timezone" example:"UTC"`
+	IncludeBots bool       `form:"include_bots" example:"false"`
+}
+
+// UnlockURLRequest represents the unlock form submitted for a password-protected URL
+type UnlockURLRequest struct {
+	Password string `form:"password" binding:"required" example:"s3cret"`
 }
 
 // RedirectRequest represents the request for URL redirection
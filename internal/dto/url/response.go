@@ -8,15 +8,83 @@ import (
 
 // URLResponse represents a URL in API responses
 type URLResponse struct {
-	ID          uint       `json:"id" example:"1"`
-	OriginalURL string     `json:"original_url" example:"https://example.com/very/long/url"`
-	ShortCode   string     `json:"short_code" example:"abc123"`
-	UserID      *uint      `json:"user_id,omitempty" example:"1"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
-	ClickCount  int64      `json:"click_count" example:"42"`
-	IsActive    bool       `json:"is_active" example:"true"`
-	CreatedAt   time.Time  `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt   time.Time  `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID             uint           `json:"id" example:"1"`
+	OriginalURL    string         `json:"original_url" example:"https://example.com/very/long/url"`
+	ShortCode      string         `json:"short_code" example:"abc123"`
+	ShortURL       string         `json:"short_url,omitempty" example:"https://go.example.com/abc123"`
+	DomainID       uint           `json:"domain_id,omitempty" example:"1"`
+	UserID         *uint          `json:"user_id,omitempty" example:"1"`
+	ActivatesAt    *time.Time     `json:"activates_at,omitempty" example:"2024-06-01T09:00:00Z"`
+	ExpiresAt      *time.Time     `json:"expires_at,omitempty" example:"2024-12-31T23:59:59Z"`
+	ClickCount     int64          `json:"click_count" example:"42"`
+	UniqueClicks   int64          `json:"unique_clicks" example:"30"`
+	BotClickCount  int64          `json:"bot_click_count" example:"3"`
+	RedirectStatus int            `json:"redirect_status,omitempty" example:"302"` // Omitted when the link follows the server default
+	HasPassword    bool           `json:"has_password" example:"false"`
+	MaxClicks      *int64         `json:"max_clicks,omitempty" example:"1"`
+	FallbackURL    string         `json:"fallback_url,omitempty" example:"https://example.com/coming-soon"`
+	ForwardQuery   bool           `json:"forward_query" example:"false"`
+	ForwardPath    bool           `json:"forward_path" example:"false"`
+	RedirectRules  []RedirectRule `json:"redirect_rules"`
+	Variants       []URLVariant   `json:"variants"`
+
+	UTM                UTMParams `json:"utm"`
+	CampaignTemplateID *uint     `json:"campaign_template_id,omitempty" example:"1"`
+
+	IsActive  bool      `json:"is_active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// RedirectRule represents a rule sending matching visitors to a different destination
+type RedirectRule struct {
+	ID        uint   `json:"id" example:"1"`
+	Position  int    `json:"position" example:"0"`
+	Type      string `json:"type" example:"os"`
+	Value     string `json:"value" example:"iOS"`
+	TargetURL string `json:"target_url" example:"https://apps.apple.com/app/id123456789"`
+}
+
+// URLVariant represents one of the weighted destinations a URL rotates between
+type URLVariant struct {
+	ID        uint   `json:"id" example:"1"`
+	Label     string `json:"label" example:"Landing page B"`
+	TargetURL string `json:"target_url" example:"https://example.com/landing-b"`
+	Weight    int    `json:"weight" example:"50"`
+}
+
+// URLVariantResponse represents the response for a single URL variant
+type URLVariantResponse struct {
+	common.BaseResponse
+	Data URLVariant `json:"data"`
+}
+
+// GetURLVariantsResponse represents the response for a URL's variants
+type GetURLVariantsResponse struct {
+	common.BaseResponse
+	Data []URLVariant `json:"data"`
+}
+
+// DeleteURLVariantResponse represents the response when deleting a URL variant
+type DeleteURLVariantResponse struct {
+	common.BaseResponse
+}
+
+// RedirectRuleResponse represents the response for a single redirect rule
+type RedirectRuleResponse struct {
+	common.BaseResponse
+	Data RedirectRule `json:"data"`
+}
+
+// GetRedirectRulesResponse represents the response for a URL's redirect rules
+type GetRedirectRulesResponse struct {
+	common.BaseResponse
+	Data []RedirectRule `json:"data"`
+}
+
+// DeleteRedirectRuleResponse represents the response when deleting a redirect rule
+type DeleteRedirectRuleResponse struct {
+	common.BaseResponse
 }
 
 // CreateURLResponse represents the response when creating a URL
@@ -25,6 +93,31 @@ type CreateURLResponse struct {
 	Data URLResponse `json:"data"`
 }
 
+// BulkCreateURLResult represents the outcome of one item of a bulk creation, with the status
+// and error code creating it alone would have returned
+type BulkCreateURLResult struct {
+	Index            int                      `json:"index" example:"0"` // Position in the request, or CSV data row counting from 0
+	Success          bool                     `json:"success" example:"true"`
+	Status           int                      `json:"status" example:"201"`
+	Data             *URLResponse             `json:"data,omitempty"`
+	Error            string                   `json:"error,omitempty" example:"Short code already exists"`
+	Code             string                   `json:"code,omitempty" example:"SHORT_CODE_ALREADY_EXISTS"`
+	ValidationErrors []common.ValidationError `json:"validation_errors,omitempty"`
+}
+
+// BulkCreateURLResults represents the per-item results of a bulk creation
+type BulkCreateURLResults struct {
+	Created int                   `json:"created" example:"2"`
+	Failed  int                   `json:"failed" example:"1"`
+	Results []BulkCreateURLResult `json:"results"`
+}
+
+// BulkCreateURLResponse represents the response when creating URLs in bulk
+type BulkCreateURLResponse struct {
+	common.BaseResponse
+	Data BulkCreateURLResults `json:"data"`
+}
+
 // GetURLsResponse represents the response when getting URLs with pagination
 type GetURLsResponse struct {
 	common.PaginatedResponse
@@ -57,15 +150,88 @@ type URLStatsResponse struct {
 // URLStats represents URL statistics
 type URLStats struct {
 	URLResponse
-	RecentClicks []ClickEvent `json:"recent_clicks,omitempty"`
+	RecentClicks []ClickEvent    `json:"recent_clicks,omitempty"`
+	Breakdowns   ClickBreakdowns `json:"breakdowns"`
+	Variants     []VariantStats  `json:"variants,omitempty"`
+}
+
+// VariantStats represents the clicks served by one of a URL's variants
+type VariantStats struct {
+	URLVariant
+	Clicks int64 `json:"clicks" example:"120"`
+}
+
+// ClickBreakdowns represents the top values of each click dimension
+type ClickBreakdowns struct {
+	Browsers         []BreakdownItem `json:"browsers"`
+	OperatingSystems []BreakdownItem `json:"operating_systems"`
+	Devices          []BreakdownItem `json:"devices"`
+	Referrers        []BreakdownItem `json:"referrers"`
+	Countries        []BreakdownItem `json:"countries"`
+	Cities           []BreakdownItem `json:"cities"`
+	Sources          []BreakdownItem `json:"sources"`
+}
+
+// BreakdownItem represents the number of clicks for a single dimension value
+type BreakdownItem struct {
+	Value  string `json:"value" example:"Chrome"`
+	Clicks int64  `json:"clicks" example:"12"`
 }
 
 // ClickEvent represents a click event
 type ClickEvent struct {
-	ID        uint      `json:"id" example:"1"`
-	URLID     uint      `json:"url_id" example:"1"`
-	IPAddress string    `json:"ip_address" example:"192.168.1.1"`
-	UserAgent string    `json:"user_agent" example:"Mozilla/5.0..."`
-	Referer   *string   `json:"referer,omitempty" example:"https://google.com"`
-	ClickedAt time.Time `json:"clicked_at" example:"2024-01-01T00:00:00Z"`
+	ID            uint      `json:"id" example:"1"`
+	URLID         uint      `json:"url_id" example:"1"`
+	IPAddress     string    `json:"ip_address" example:"192.168.1.1"`
+	UserAgent     string    `json:"user_agent" example:"Mozilla/5.0..."`
+	Referer       *string   `json:"referer,omitempty" example:"https://google.com"`
+	Browser       string    `json:"browser" example:"Chrome"`
+	OS            string    `json:"os" example:"macOS"`
+	DeviceType    string    `json:"device_type" example:"desktop"`
+	RefererDomain string    `json:"referer_domain,omitempty" example:"google.com"`
+	IsBot         bool      `json:"is_bot" example:"false"`
+	Country       string    `json:"country,omitempty" example:"US"`
+	City          string    `json:"city,omitempty" example:"San Francisco"`
+	VariantID     *uint     `json:"variant_id,omitempty" example:"1"`
+	Source        string    `json:"source,omitempty" example:"qr"`
+	ClickedAt     time.Time `json:"clicked_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// URLTimeSeriesResponse represents the response for the click time series
+type URLTimeSeriesResponse struct {
+	common.BaseResponse
+	Data URLTimeSeries `json:"data"`
+}
+
+// URLTimeSeries represents clicks aggregated into time buckets
+type URLTimeSeries struct {
+	URLID    uint              `json:"url_id" example:"1"`
+	Interval string            `json:"interval" example:"day"`
+	Timezone string            `json:"timezone" example:"UTC"`
+	From     time.Time         `json:"from" example:"2024-01-01T00:00:00Z"`
+	To       time.Time         `json:"to" example:"2024-01-31T00:00:00Z"`
+	Total    int64             `json:"total" example:"42"`
+	Points   []TimeSeriesPoint `json:"points"`
+}
+
+// TimeSeriesPoint represents the number of clicks in a single bucket
+type TimeSeriesPoint struct {
+	Timestamp time.Time `json:"timestamp" example:"2024-01-01T00:00:00Z"`
+	Clicks    int64     `json:"clicks" example:"7"`
+}
+
+// ClickQueueMetricsResponse represents the response for click queue metrics
+type ClickQueueMetricsResponse struct {
+	common.BaseResponse
+	Data ClickQueueMetrics `json:"data"`
+}
+
+// ClickQueueMetrics represents counters for the asynchronous click queue
+type ClickQueueMetrics struct {
+	Enqueued      uint64 `json:"enqueued" example:"1200"`
+	Dropped       uint64 `json:"dropped" example:"0"`
+	Flushed       uint64 `json:"flushed" example:"1180"`
+	Failed        uint64 `json:"failed" example:"0"`
+	QueueLength   int    `json:"queue_length" example:"20"`
+	QueueCapacity int    `json:"queue_capacity" example:"10000"`
 }
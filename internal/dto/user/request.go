@@ -17,3 +17,8 @@ type GetUsersRequest struct {
 	SortBy   string `form:"sort_by,default=created_at" example:"created_at"`
 	SortDir  string `form:"sort_dir,default=desc" binding:"oneof=asc desc" example:"desc"`
 }
+
+// UpdateUserSettingsRequest represents the request body for updating the current user's settings
+type UpdateUserSettingsRequest struct {
+	DefaultFallbackURL *string `json:"default_fallback_url,omitempty" binding:"omitempty,len=0|url" example:"https://example.com/link-unavailable"` // An empty string removes the fallback
+}
@@ -13,6 +13,7 @@ type UserResponse struct {
 	Username  string    `json:"username" example:"johndoe"`
 	FullName  string    `json:"full_name" example:"John Doe"`
 	IsActive  bool      `json:"is_active" example:"true"`
+	IsAdmin   bool      `json:"is_admin" example:"false"`
 	CreatedAt time.Time `json:"created_at" example:"2024-01-01T12:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T12:00:00Z"`
 }
@@ -34,3 +35,20 @@ type UpdateUserResponse struct {
 	common.BaseResponse
 	Data UserResponse `json:"data"`
 }
+
+// UserSettings represents the current user's link settings
+type UserSettings struct {
+	DefaultFallbackURL string `json:"default_fallback_url" example:"https://example.com/link-unavailable"`
+}
+
+// GetUserSettingsResponse represents the response for getting the current user's settings
+type GetUserSettingsResponse struct {
+	common.BaseResponse
+	Data UserSettings `json:"data"`
+}
+
+// UpdateUserSettingsResponse represents the response for updating the current user's settings
+type UpdateUserSettingsResponse struct {
+	common.BaseResponse
+	Data UserSettings `json:"data"`
+}
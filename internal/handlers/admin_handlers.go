@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/admin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/middleware"
+	"github.com/tinwritescode/myapp/internal/service"
+)
+
+func getReservedCodeService() service.ReservedCodeService {
+	return service.GetReservedCodeService()
+}
+
+// @Summary Get reserved codes
+// @Description Get the short codes and words admins have taken out of use. Route names and the configured
+// @Description reserved words and blocklist apply as well but are not listed.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} admin.GetReservedCodesResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 403 {object} common.ErrorResponse
+// @Router /admin/reserved-codes [get]
+func GetReservedCodes(c *gin.Context) {
+	reservedCodeService := getReservedCodeService()
+	reservedCodes, err := reservedCodeService.GetReservedCodes()
+	if err != nil {
+		handleAdminError(c, err)
+		return
+	}
+
+	data := make([]admin.ReservedCode, len(reservedCodes))
+	for i := range reservedCodes {
+		data[i] = reservedCodes[i].ToResponse()
+	}
+
+	response := admin.GetReservedCodesResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Reserved codes retrieved successfully",
+		},
+		Data: data,
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Reserve code
+// @Description Take a short code (type reserved) or every code containing a word (type blocked) out of use for
+// @Description new links. Existing links keep working.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body admin.CreateReservedCodeRequest true "Code details"
+// @Success 201 {object} admin.ReservedCodeResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 403 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse
+// @Router /admin/reserved-codes [post]
+func CreateReservedCode(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	var req admin.CreateReservedCodeRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	reservedCodeService := getReservedCodeService()
+	reservedCode, err := reservedCodeService.CreateReservedCode(userID, req)
+	if err != nil {
+		handleAdminError(c, err)
+		return
+	}
+
+	response := admin.ReservedCodeResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Code reserved successfully",
+		},
+		Data: reservedCode.ToResponse(),
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Delete reserved code
+// @Description Make a reserved code or blocked word available again
+// @Tags admin
+// @Produce json
+// @Param id path int true "Reserved code ID"
+// @Success 200 {object} admin.DeleteReservedCodeResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 403 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /admin/reserved-codes/{id} [delete]
+func DeleteReservedCode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid reserved code ID"))
+		return
+	}
+
+	reservedCodeService := getReservedCodeService()
+	if err := reservedCodeService.DeleteReservedCode(uint(id)); err != nil {
+		handleAdminError(c, err)
+		return
+	}
+
+	response := admin.DeleteReservedCodeResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Reserved code deleted successfully",
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// handleAdminError handles admin errors
+func handleAdminError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	if appErr, ok := err.(*common.AppError); ok {
+		switch appErr.Code {
+		case common.VALIDATION_ERROR:
+			statusCode = http.StatusBadRequest
+		case common.NOT_FOUND:
+			statusCode = http.StatusNotFound
+		case common.CONFLICT:
+			statusCode = http.StatusConflict
+		case common.INTERNAL_SERVER_ERROR:
+			statusCode = http.StatusInternalServerError
+		}
+		c.JSON(statusCode, common.NewErrorResponseWithCode(appErr.Code, appErr.Message))
+	} else {
+		c.JSON(statusCode, common.NewErrorResponse(err.Error()))
+	}
+}
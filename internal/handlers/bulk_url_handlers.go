@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/middleware"
+)
+
+// Maximum number of URLs per bulk request - will be set from config
+var bulkCreateLimit = 500
+
+// SetBulkCreateLimit sets how many URLs a bulk request may create
+func SetBulkCreateLimit(limit int) {
+	bulkCreateLimit = limit
+}
+
+// @Summary Create URLs in bulk
+// @Description Create many short URLs at once, either as JSON or as a CSV file (text/csv body, or multipart/form-data
+// @Description with the file in the "file" field). CSV columns are named like the JSON fields, with utm_source,
+// @Description utm_medium, utm_campaign, utm_term and utm_content for UTM parameters; original_url is required.
+// @Description Each item is created on its own: the response lists every item's result with the status and error code
+// @Description creating it alone would have returned. 201 means every item was created.
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Param request body url.BulkCreateURLRequest true "URLs to create"
+// @Success 200 {object} url.BulkCreateURLResponse "Some items failed"
+// @Success 201 {object} url.BulkCreateURLResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Router /urls/bulk [post]
+func BulkCreateURLs(c *gin.Context) {
+	var reqs []url.CreateURLRequest
+	var rowErrors [][]common.ValidationError
+	var err error
+
+	switch c.ContentType() {
+	case "multipart/form-data":
+		fileHeader, formErr := c.FormFile("file")
+		if formErr != nil {
+			c.JSON(http.StatusBadRequest, common.NewErrorResponseWithCode(common.VALIDATION_ERROR, "A CSV file is required in the file field"))
+			return
+		}
+		file, openErr := fileHeader.Open()
+		if openErr != nil {
+			c.JSON(http.StatusBadRequest, common.NewErrorResponseWithCode(common.VALIDATION_ERROR, "Failed to read CSV file"))
+			return
+		}
+		defer file.Close()
+		reqs, rowErrors, err = readURLsCSV(file, bulkCreateLimit)
+	case "text/csv":
+		reqs, rowErrors, err = readURLsCSV(c.Request.Body, bulkCreateLimit)
+	default:
+		var req url.BulkCreateURLRequest
+		if !middleware.BindJSON(c, &req) {
+			return
+		}
+		reqs = req.URLs
+	}
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponseWithCode(common.VALIDATION_ERROR, err.Error()))
+		return
+	}
+	if len(reqs) == 0 {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponseWithCode(common.VALIDATION_ERROR, "No URLs to create"))
+		return
+	}
+	if len(reqs) > bulkCreateLimit {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponseWithCode(common.VALIDATION_ERROR,
+			fmt.Sprintf("At most %d URLs can be created at once", bulkCreateLimit)))
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	urlService := getURLService()
+	results := url.BulkCreateURLResults{
+		Results: make([]url.BulkCreateURLResult, len(reqs)),
+	}
+	for i := range reqs {
+		result := url.BulkCreateURLResult{Index: i}
+
+		var validationErrors []common.ValidationError
+		if rowErrors != nil {
+			validationErrors = rowErrors[i]
+		}
+		if len(validationErrors) == 0 {
+			validationErrors = middleware.ValidateStruct(&reqs[i])
+		}
+
+		if len(validationErrors) > 0 {
+			result.Status = http.StatusBadRequest
+			result.Error = "Validation failed"
+			result.Code = common.VALIDATION_ERROR.String()
+			result.ValidationErrors = validationErrors
+		} else if createdURL, err := urlService.CreateURL(reqs[i], userID); err != nil {
+			result.Status = urlErrorStatus(err)
+			result.Error = err.Error()
+			if appErr, ok := err.(*common.AppError); ok {
+				result.Error = appErr.Message
+				result.Code = appErr.Code.String()
+			}
+		} else {
+			data := createdURL.ToResponse()
+			result.Success = true
+			result.Status = http.StatusCreated
+			result.Data = &data
+		}
+
+		if result.Success {
+			results.Created++
+		} else {
+			results.Failed++
+		}
+		results.Results[i] = result
+	}
+
+	statusCode := http.StatusCreated
+	if results.Failed > 0 {
+		statusCode = http.StatusOK
+	}
+
+	response := url.BulkCreateURLResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: fmt.Sprintf("Created %d of %d URLs", results.Created, len(reqs)),
+		},
+		Data: results,
+	}
+
+	c.JSON(statusCode, response)
+}
+
+// csvURLColumn sets a field of a CreateURLRequest from a non-empty CSV cell
+type csvURLColumn func(req *url.CreateURLRequest, value string) error
+
+// csvURLColumns maps CSV headers, named like the JSON fields, to the fields they set
+var csvURLColumns = map[string]csvURLColumn{
+	"original_url": func(req *url.CreateURLRequest, value string) error {
+		req.OriginalURL = value
+		return nil
+	},
+	"short_code": func(req *url.CreateURLRequest, value string) error {
+		req.ShortCode = &value
+		return nil
+	},
+	"domain_id": func(req *url.CreateURLRequest, value string) (err error) {
+		req.DomainID, err = parseCSVUint(value)
+		return err
+	},
+	"campaign_template_id": func(req *url.CreateURLRequest, value string) (err error) {
+		req.CampaignTemplateID, err = parseCSVUint(value)
+		return err
+	},
+	"activates_at": func(req *url.CreateURLRequest, value string) (err error) {
+		req.ActivatesAt, err = parseCSVTime(value)
+		return err
+	},
+	"expires_at": func(req *url.CreateURLRequest, value string) (err error) {
+		req.ExpiresAt, err = parseCSVTime(value)
+		return err
+	},
+	"redirect_status": func(req *url.CreateURLRequest, value string) error {
+		status, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		req.RedirectStatus = &status
+		return nil
+	},
+	"password": func(req *url.CreateURLRequest, value string) error {
+		req.Password = &value
+		return nil
+	},
+	"max_clicks": func(req *url.CreateURLRequest, value string) error {
+		maxClicks, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		req.MaxClicks = &maxClicks
+		return nil
+	},
+	"fallback_url": func(req *url.CreateURLRequest, value string) error {
+		req.FallbackURL = &value
+		return nil
+	},
+	"forward_query": func(req *url.CreateURLRequest, value string) (err error) {
+		req.ForwardQuery, err = parseCSVBool(value)
+		return err
+	},
+	"forward_path": func(req *url.CreateURLRequest, value string) (err error) {
+		req.ForwardPath, err = parseCSVBool(value)
+		return err
+	},
+	"utm_source":   csvUTMColumn(func(utm *url.UTMParams) *string { return &utm.Source }),
+	"utm_medium":   csvUTMColumn(func(utm *url.UTMParams) *string { return &utm.Medium }),
+	"utm_campaign": csvUTMColumn(func(utm *url.UTMParams) *string { return &utm.Campaign }),
+	"utm_term":     csvUTMColumn(func(utm *url.UTMParams) *string { return &utm.Term }),
+	"utm_content":  csvUTMColumn(func(utm *url.UTMParams) *string { return &utm.Content }),
+}
+
+// csvUTMColumn returns a column setting one UTM parameter
+func csvUTMColumn(field func(utm *url.UTMParams) *string) csvURLColumn {
+	return func(req *url.CreateURLRequest, value string) error {
+		if req.UTM == nil {
+			req.UTM = &url.UTMParams{}
+		}
+		*field(req.UTM) = value
+		return nil
+	}
+}
+
+func parseCSVUint(value string) (*uint, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, errors.New("must be a whole number")
+	}
+	parsed := uint(n)
+	return &parsed, nil
+}
+
+func parseCSVTime(value string) (*time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New("must be an RFC 3339 time such as 2024-12-31T23:59:59Z")
+	}
+	return &parsed, nil
+}
+
+func parseCSVBool(value string) (*bool, error) {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, errors.New("must be true or false")
+	}
+	return &parsed, nil
+}
+
+// readURLsCSV reads creation requests from a CSV file with a header row, stopping once it has
+// read more than limit rows. Cells that can't be parsed are reported per row rather than
+// failing the whole file, so the other rows can still be created.
+func readURLsCSV(r io.Reader, limit int) ([]url.CreateURLRequest, [][]common.ValidationError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.New("CSV file must start with a header row")
+	}
+
+	columns := make([]string, len(header))
+	hasOriginalURL := false
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := csvURLColumns[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown CSV column %q", name)
+		}
+		columns[i] = name
+		hasOriginalURL = hasOriginalURL || name == "original_url"
+	}
+	if !hasOriginalURL {
+		return nil, nil, errors.New("CSV file must have an original_url column")
+	}
+
+	var reqs []url.CreateURLRequest
+	var rowErrors [][]common.ValidationError
+	for len(reqs) <= limit {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		var req url.CreateURLRequest
+		var validationErrors []common.ValidationError
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if i >= len(columns) || value == "" {
+				continue
+			}
+			if err := csvURLColumns[columns[i]](&req, value); err != nil {
+				validationErrors = append(validationErrors, common.ValidationError{
+					Field:   columns[i],
+					Message: fmt.Sprintf("%s %s", columns[i], err.Error()),
+					Value:   value,
+				})
+			}
+		}
+
+		reqs = append(reqs, req)
+		rowErrors = append(rowErrors, validationErrors)
+	}
+
+	return reqs, rowErrors, nil
+}
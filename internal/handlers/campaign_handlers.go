@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/campaign"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/middleware"
+	"github.com/tinwritescode/myapp/internal/service"
+)
+
+func getCampaignService() service.CampaignService {
+	return service.GetCampaignService()
+}
+
+// @Summary Get campaign templates
+// @Description Get the current user's campaign templates
+// @Tags campaigns
+// @Produce json
+// @Success 200 {object} campaign.GetCampaignTemplatesResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Router /campaigns [get]
+func GetCampaignTemplates(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	campaignService := getCampaignService()
+	templates, err := campaignService.GetTemplates(userID)
+	if err != nil {
+		handleCampaignError(c, err)
+		return
+	}
+
+	data := make([]campaign.CampaignTemplate, len(templates))
+	for i := range templates {
+		data[i] = templates[i].ToResponse()
+	}
+
+	response := campaign.GetCampaignTemplatesResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Campaign templates retrieved successfully",
+		},
+		Data: data,
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Get campaign template
+// @Description Get one of the current user's campaign templates
+// @Tags campaigns
+// @Produce json
+// @Param id path int true "Campaign template ID"
+// @Success 200 {object} campaign.CampaignTemplateResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /campaigns/{id} [get]
+func GetCampaignTemplate(c *gin.Context) {
+	id, userID, ok := parseCampaignRequest(c)
+	if !ok {
+		return
+	}
+
+	campaignService := getCampaignService()
+	template, err := campaignService.GetTemplate(id, userID)
+	if err != nil {
+		handleCampaignError(c, err)
+		return
+	}
+
+	response := campaign.CampaignTemplateResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Campaign template retrieved successfully",
+		},
+		Data: template.ToResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Create campaign template
+// @Description Save a reusable set of UTM parameters. Links using the template have them added to their destination,
+// @Description except for parameters the link sets itself or the destination already contains.
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param request body campaign.CreateCampaignTemplateRequest true "Campaign template details"
+// @Success 201 {object} campaign.CampaignTemplateResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse
+// @Router /campaigns [post]
+func CreateCampaignTemplate(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	var req campaign.CreateCampaignTemplateRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	campaignService := getCampaignService()
+	template, err := campaignService.CreateTemplate(userID, req)
+	if err != nil {
+		handleCampaignError(c, err)
+		return
+	}
+
+	response := campaign.CampaignTemplateResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Campaign template created successfully",
+		},
+		Data: template.ToResponse(),
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Update campaign template
+// @Description Update a campaign template. Changes apply to every link using it.
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign template ID"
+// @Param request body campaign.UpdateCampaignTemplateRequest true "Campaign template update details"
+// @Success 200 {object} campaign.CampaignTemplateResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse
+// @Router /campaigns/{id} [put]
+func UpdateCampaignTemplate(c *gin.Context) {
+	id, userID, ok := parseCampaignRequest(c)
+	if !ok {
+		return
+	}
+
+	var req campaign.UpdateCampaignTemplateRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	campaignService := getCampaignService()
+	template, err := campaignService.UpdateTemplate(id, userID, req)
+	if err != nil {
+		handleCampaignError(c, err)
+		return
+	}
+
+	response := campaign.CampaignTemplateResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Campaign template updated successfully",
+		},
+		Data: template.ToResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Delete campaign template
+// @Description Delete a campaign template. Links using it keep their own UTM parameters.
+// @Tags campaigns
+// @Produce json
+// @Param id path int true "Campaign template ID"
+// @Success 200 {object} campaign.DeleteCampaignTemplateResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /campaigns/{id} [delete]
+func DeleteCampaignTemplate(c *gin.Context) {
+	id, userID, ok := parseCampaignRequest(c)
+	if !ok {
+		return
+	}
+
+	campaignService := getCampaignService()
+	if err := campaignService.DeleteTemplate(id, userID); err != nil {
+		handleCampaignError(c, err)
+		return
+	}
+
+	response := campaign.DeleteCampaignTemplateResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Campaign template deleted successfully",
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseCampaignRequest reads the template ID from the path and the current user, writing an
+// error response if either is missing
+func parseCampaignRequest(c *gin.Context) (uint, uint, bool) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid campaign template ID"))
+		return 0, 0, false
+	}
+
+	return uint(id), userID, true
+}
+
+// handleCampaignError handles campaign template errors
+func handleCampaignError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	if appErr, ok := err.(*common.AppError); ok {
+		switch appErr.Code {
+		case common.VALIDATION_ERROR:
+			statusCode = http.StatusBadRequest
+		case common.NOT_FOUND:
+			statusCode = http.StatusNotFound
+		case common.CONFLICT:
+			statusCode = http.StatusConflict
+		case common.INTERNAL_SERVER_ERROR:
+			statusCode = http.StatusInternalServerError
+		}
+		c.JSON(statusCode, common.NewErrorResponseWithCode(appErr.Code, appErr.Message))
+	} else {
+		c.JSON(statusCode, common.NewErrorResponse(err.Error()))
+	}
+}
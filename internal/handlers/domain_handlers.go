@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/domain"
+	"github.com/tinwritescode/myapp/internal/middleware"
+	"github.com/tinwritescode/myapp/internal/service"
+)
+
+func getDomainService() service.DomainService {
+	return service.GetDomainService()
+}
+
+// @Summary Get domains
+// @Description Get the current user's custom domains
+// @Tags domains
+// @Produce json
+// @Success 200 {object} domain.GetDomainsResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Router /domains [get]
+func GetDomains(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	domainService := getDomainService()
+	domains, err := domainService.GetDomains(userID)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	data := make([]domain.Domain, len(domains))
+	for i := range domains {
+		data[i] = domains[i].ToResponse()
+	}
+
+	response := domain.GetDomainsResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Domains retrieved successfully",
+		},
+		Data: data,
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Add domain
+// @Description Add a custom domain to serve short links from. Publish the returned TXT record, then verify the domain
+// @Description before creating links on it. The domain's DNS must also point at this server.
+// @Tags domains
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateDomainRequest true "Domain details"
+// @Success 201 {object} domain.DomainResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse
+// @Router /domains [post]
+func CreateDomain(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	var req domain.CreateDomainRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	domainService := getDomainService()
+	newDomain, err := domainService.CreateDomain(userID, req)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	response := domain.DomainResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Domain added successfully",
+		},
+		Data: newDomain.ToResponse(),
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Verify domain
+// @Description Check the domain's verification TXT record and mark it verified if the token matches
+// @Tags domains
+// @Produce json
+// @Param id path int true "Domain ID"
+// @Success 200 {object} domain.DomainResponse
+// @Failure 400 {object} common.ErrorResponse "Verification record missing or wrong"
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse "Domain verified by another user"
+// @Router /domains/{id}/verify [post]
+func VerifyDomain(c *gin.Context) {
+	id, userID, ok := parseDomainRequest(c)
+	if !ok {
+		return
+	}
+
+	domainService := getDomainService()
+	verifiedDomain, err := domainService.VerifyDomain(id, userID)
+	if err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	response := domain.DomainResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Domain verified successfully",
+		},
+		Data: verifiedDomain.ToResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Delete domain
+// @Description Delete a custom domain that no longer has any links
+// @Tags domains
+// @Produce json
+// @Param id path int true "Domain ID"
+// @Success 200 {object} domain.DeleteDomainResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse "Domain still has links"
+// @Router /domains/{id} [delete]
+func DeleteDomain(c *gin.Context) {
+	id, userID, ok := parseDomainRequest(c)
+	if !ok {
+		return
+	}
+
+	domainService := getDomainService()
+	if err := domainService.DeleteDomain(id, userID); err != nil {
+		handleDomainError(c, err)
+		return
+	}
+
+	response := domain.DeleteDomainResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Domain deleted successfully",
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseDomainRequest reads the domain ID from the path and the current user, writing an
+// error response if either is missing
+func parseDomainRequest(c *gin.Context) (uint, uint, bool) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid domain ID"))
+		return 0, 0, false
+	}
+
+	return uint(id), userID, true
+}
+
+// handleDomainError handles domain errors
+func handleDomainError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	if appErr, ok := err.(*common.AppError); ok {
+		switch appErr.Code {
+		case common.VALIDATION_ERROR:
+			statusCode = http.StatusBadRequest
+		case common.NOT_FOUND:
+			statusCode = http.StatusNotFound
+		case common.CONFLICT:
+			statusCode = http.StatusConflict
+		case common.INTERNAL_SERVER_ERROR:
+			statusCode = http.StatusInternalServerError
+		}
+		c.JSON(statusCode, common.NewErrorResponseWithCode(appErr.Code, appErr.Message))
+	} else {
+		c.JSON(statusCode, common.NewErrorResponse(err.Error()))
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/service"
 )
 
 // @Summary Ping
@@ -19,3 +22,32 @@ func Ping(c *gin.Context) {
 		"data":    nil,
 	})
 }
+
+// @Summary Click queue metrics
+// @Description Get counters for the asynchronous click recording queue
+// @Tags metrics
+// @Produce json
+// @Success 200 {object} url.ClickQueueMetricsResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 403 {object} common.ErrorResponse
+// @Router /admin/metrics/clicks [get]
+func GetClickQueueMetrics(c *gin.Context) {
+	stats := service.GetClickRecorder().Stats()
+
+	response := url.ClickQueueMetricsResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Click queue metrics retrieved successfully",
+		},
+		Data: url.ClickQueueMetrics{
+			Enqueued:      stats.Enqueued,
+			Dropped:       stats.Dropped,
+			Flushed:       stats.Flushed,
+			Failed:        stats.Failed,
+			QueueLength:   stats.QueueLength,
+			QueueCapacity: stats.QueueCapacity,
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
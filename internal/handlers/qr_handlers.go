@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/middleware"
+	"github.com/tinwritescode/myapp/internal/service"
+	"github.com/tinwritescode/myapp/pkg/qrcode"
+)
+
+// @Summary Get URL QR code
+// @Description Render a QR code of the link's full short URL, on its custom domain if it has one.
+// @Description The encoded URL carries a marker so scans are reported as the "qr" source in click statistics.
+// @Tags urls
+// @Produce png
+// @Produce image/svg+xml
+// @Param id path int true "URL ID"
+// @Param format query string false "Image format" Enums(png, svg) default(png)
+// @Param size query int false "Width and height in pixels" minimum(64) maximum(2048) default(256)
+// @Param ecc query string false "Error correction level" Enums(L, M, Q, H) default(M)
+// @Param fg query string false "Foreground colour as RRGGBB" default(000000)
+// @Param bg query string false "Background colour as RRGGBB" default(ffffff)
+// @Param quiet_zone query int false "Blank border in modules" minimum(0) maximum(16) default(4)
+// @Success 200 {file} file "QR code image"
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/qr [get]
+func GetURLQRCode(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return
+	}
+
+	var req url.GetQRCodeRequest
+	if !middleware.BindQuery(c, &req) {
+		return
+	}
+
+	opts := qrcode.Options{
+		Size:      req.Size,
+		Level:     req.ECC,
+		QuietZone: req.QuietZone,
+	}
+	if opts.Foreground, err = qrcode.ParseColor(req.Foreground); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid foreground colour"))
+		return
+	}
+	if opts.Background, err = qrcode.ParseColor(req.Background); err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid background colour"))
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	urlService := getURLService()
+	urlData, err := urlService.GetURLByID(uint(id), userID)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	content, err := service.QRCodeContent(urlData)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	render, contentType := qrcode.PNG, "image/png"
+	if req.Format == "svg" {
+		render, contentType = qrcode.SVG, "image/svg+xml"
+	}
+
+	image, err := render(content, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse(fmt.Sprintf("Failed to render QR code: %s", err.Error())))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.%s"`, urlData.ShortCode, req.Format))
+	c.Data(http.StatusOK, contentType, image)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/internal/service"
+	"github.com/tinwritescode/myapp/pkg/utils"
+)
+
+// @Summary Redirect to original URL
+// @Description Redirect to the original URL using short code, looked up on the custom domain named by the Host header if it is a verified one.
+// @Description Visitors matching a device, OS or country rule go to the target of the first matching rule instead.
+// @Description URLs with variants redirect to one of them by weight, keeping each visitor on the same variant via a cookie.
+// @Description Password-protected links render an unlock form instead.
+// @Description Unavailable links redirect to their fallback URL, or render the server's fallback page for browsers.
+// @Description Links that forward the query string or path append those of the request to the destination, keeping parameters the destination already sets.
+// @Description Paths are given after the short code, as in /{short_code}/rest/of/path, and are only accepted by links that forward them.
+// @Tags urls
+// @Produce html
+// @Param short_code path string true "Short code"
+// @Success 200 {string} string "Unlock form for a password-protected link"
+// @Success 301 {string} string "Permanent redirect to original URL"
+// @Success 302 {string} string "Redirect to original URL, or to the fallback URL of an unavailable link"
+// @Success 307 {string} string "Temporary redirect preserving the request method"
+// @Success 308 {string} string "Permanent redirect preserving the request method"
+// @Failure 403 {object} common.ErrorResponse "Link not active yet, or an automated client opening a click-limited link"
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 410 {object} common.ErrorResponse "Link expired or reached its click limit"
+// @Router /{short_code} [get]
+func RedirectURL(c *gin.Context) {
+	shortCode := c.Param("short_code")
+	if shortCode == "" {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Short code is required"))
+		return
+	}
+
+	urlData := resolveShortCode(c, shortCode)
+	if urlData == nil {
+		return
+	}
+
+	// Protected links are only counted once they are unlocked
+	if urlData.HasPassword() {
+		renderUnlockPage(c, http.StatusOK, "")
+		return
+	}
+
+	// Redirect to original URL
+	followLink(c, urlData, service.RedirectStatusFor(urlData))
+}
+
+// @Summary Unlock password-protected URL
+// @Description Submit the password for a protected link and redirect to the original URL
+// @Tags urls
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param short_code path string true "Short code"
+// @Param password formData string true "Link password"
+// @Success 303 {string} string "Redirect to original URL"
+// @Failure 401 {string} string "Unlock form with an error message"
+// @Failure 403 {object} common.ErrorResponse "Link not active yet, or an automated client opening a click-limited link"
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 410 {object} common.ErrorResponse "Link expired or reached its click limit"
+// @Failure 429 {string} string "Unlock form with an error message"
+// @Router /{short_code} [post]
+func UnlockURL(c *gin.Context) {
+	shortCode := c.Param("short_code")
+
+	urlData := resolveShortCode(c, shortCode)
+	if urlData == nil {
+		return
+	}
+
+	var req url.UnlockURLRequest
+	if err := c.ShouldBind(&req); err != nil {
+		renderUnlockPage(c, http.StatusBadRequest, "Please enter the password")
+		return
+	}
+
+	if err := getURLService().UnlockURL(urlData, req.Password, c.ClientIP()); err != nil {
+		statusCode := http.StatusInternalServerError
+		message := "Something went wrong, please try again"
+		if appErr, ok := err.(*common.AppError); ok {
+			switch appErr.Code {
+			case common.INVALID_CREDENTIALS:
+				statusCode = http.StatusUnauthorized
+			case common.TOO_MANY_REQUESTS:
+				statusCode = http.StatusTooManyRequests
+			}
+			message = appErr.Message
+		}
+		renderUnlockPage(c, statusCode, message)
+		return
+	}
+
+	// Always 303 so the browser follows with a GET and never re-posts the password
+	followLink(c, urlData, http.StatusSeeOther)
+}
+
+// resolveShortCode looks up the URL to redirect through. Visitors of a link that is
+// unavailable are sent to the link's or its owner's fallback URL, or shown the server's
+// fallback page if they are browsing. It returns nil once a response has been written.
+func resolveShortCode(c *gin.Context, shortCode string) *models.URL {
+	// Short codes are scoped to the custom domain the request was made on
+	domainID, err := getDomainService().ResolveHost(c.Request.Host)
+	if err != nil {
+		handleURLError(c, err)
+		return nil
+	}
+
+	urlService := getURLService()
+	urlData, err := urlService.GetURLByShortCode(domainID, shortCode)
+	if err == nil {
+		// A path after the short code only resolves for links that forward it
+		if !urlData.ForwardPath && strings.Trim(c.Param("path"), "/") != "" {
+			handleURLError(c, common.NewAppError(common.URL_NOT_FOUND, "URL not found", nil))
+			return nil
+		}
+		return urlData
+	}
+
+	if urlData != nil {
+		handleUnavailableURL(c, urlData, err)
+		return nil
+	}
+
+	handleURLError(c, err)
+	return nil
+}
+
+// handleUnavailableURL sends visitors of a URL that can't be followed to the link's or its
+// owner's fallback URL, or shows browsers the server's fallback page
+func handleUnavailableURL(c *gin.Context, urlData *models.URL, err error) {
+	if fallbackURL := getURLService().FallbackURLFor(urlData); fallbackURL != "" {
+		c.Header("Cache-Control", "no-store")
+		c.Redirect(http.StatusFound, fallbackURL)
+		return
+	}
+
+	message := err.Error()
+	if appErr, ok := err.(*common.AppError); ok {
+		message = appErr.Message
+	}
+	if renderFallbackPage(c, urlErrorStatus(err), message) {
+		return
+	}
+
+	handleURLError(c, err)
+}
+
+// followLink records a click and redirects to the destination chosen by the URL's redirect
+// rules or variants. Click-limited links claim their click first, and refuse bots so link
+// previews can't use them up.
+func followLink(c *gin.Context, urlData *models.URL, statusCode int) {
+	userAgent := utils.ParseUserAgent(c.Request.UserAgent())
+
+	counted := false
+	if urlData.MaxClicks != nil {
+		if userAgent.IsBot {
+			handleURLError(c, common.NewAppError(common.FORBIDDEN, "Click-limited links can't be opened by automated clients", nil))
+			return
+		}
+		if err := getURLService().ClaimClick(urlData); err != nil {
+			// Losing the race for the last click makes the link unavailable like any other
+			if appErr, ok := err.(*common.AppError); ok && appErr.Code == common.URL_EXPIRED {
+				handleUnavailableURL(c, urlData, err)
+				return
+			}
+			handleURLError(c, err)
+			return
+		}
+		counted = true
+	}
+
+	visitor := &service.Visitor{
+		DeviceType:      userAgent.DeviceType,
+		OS:              userAgent.OS,
+		IP:              c.ClientIP(),
+		StickyVariantID: stickyVariantID(c, urlData),
+	}
+	destination, variant := service.DestinationFor(urlData, visitor)
+	destination = forwardRequest(c, urlData, destination)
+
+	event := newClickEvent(c, urlData, userAgent)
+	event.Counted = counted
+	if c.Query(service.QRScanParam) == "1" {
+		event.Source = models.ClickSourceQR
+	}
+	// Reuse the location if a country rule already looked it up
+	if location, ok := visitor.ResolvedLocation(); ok {
+		event.Country = location.Country
+		event.City = location.City
+	}
+	if variant != nil {
+		event.VariantID = &variant.ID
+		setStickyVariant(c, urlData, variant)
+	}
+
+	// Queue the click so the redirect never waits on the database
+	service.GetClickRecorder().Enqueue(event)
+
+	c.Redirect(statusCode, destination)
+}
+
+// forwardRequest appends the path after the short code and the query string of the request
+// to the destination, for links that forward them. Parameters the destination already sets,
+// including its UTM parameters, are kept.
+func forwardRequest(c *gin.Context, urlData *models.URL, destination string) string {
+	if urlData.ForwardPath {
+		if forwarded, err := utils.AppendPath(destination, c.Param("path")); err == nil {
+			destination = forwarded
+		}
+	}
+
+	if urlData.ForwardQuery {
+		query := c.Request.URL.Query()
+		query.Del(service.QRScanParam)
+		if forwarded, err := utils.MergeQueryParams(destination, query); err == nil {
+			destination = forwarded
+		}
+	}
+
+	return destination
+}
+
+// newClickEvent describes a visit to the URL from the request headers
+func newClickEvent(c *gin.Context, urlData *models.URL, userAgent utils.UserAgentInfo) models.ClickEvent {
+	event := models.ClickEvent{
+		URLID:      urlData.ID,
+		ClickedAt:  time.Now(),
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		Browser:    userAgent.Browser,
+		OS:         userAgent.OS,
+		DeviceType: userAgent.DeviceType,
+		IsBot:      userAgent.IsBot,
+	}
+	if referer := c.Request.Referer(); referer != "" {
+		event.Referer = &referer
+		event.RefererDomain = utils.GetRefererDomain(referer)
+	}
+	return event
+}
+
+const (
+	// variantCookiePrefix names the cookie holding the variant served to a visitor, followed by
+	// the link's ID. Cookie paths are matched case-sensitively, so the cookie is scoped to the
+	// whole site rather than the short code as typed.
+	variantCookiePrefix = "link_variant_"
+	// variantCookieMaxAge keeps visitors on the same variant for the length of an experiment
+	variantCookieMaxAge = 30 * 24 * 60 * 60
+)
+
+// stickyVariantID returns the variant previously served to the visitor for this URL, or 0
+func stickyVariantID(c *gin.Context, urlData *models.URL) uint {
+	if len(urlData.Variants) == 0 {
+		return 0
+	}
+
+	value, err := c.Cookie(variantCookieName(urlData))
+	if err != nil {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
+
+// setStickyVariant remembers the variant served so the visitor sees it again next time
+func setStickyVariant(c *gin.Context, urlData *models.URL, variant *models.URLVariant) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(variantCookieName(urlData), strconv.FormatUint(uint64(variant.ID), 10), variantCookieMaxAge, "/", "", false, true)
+}
+
+// variantCookieName returns the name of the cookie holding the variant served for a URL
+func variantCookieName(urlData *models.URL) string {
+	return variantCookiePrefix + strconv.FormatUint(uint64(urlData.ID), 10)
+}
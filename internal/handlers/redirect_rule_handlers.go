@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/middleware"
+	"github.com/tinwritescode/myapp/internal/service"
+)
+
+func getRedirectRuleService() service.RedirectRuleService {
+	return service.GetRedirectRuleService()
+}
+
+// @Summary Get redirect rules
+// @Description Get a URL's redirect rules in evaluation order
+// @Tags urls
+// @Produce json
+// @Param id path int true "URL ID"
+// @Success 200 {object} url.GetRedirectRulesResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/rules [get]
+func GetRedirectRules(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	ruleService := getRedirectRuleService()
+	rules, err := ruleService.GetRules(uint(id), userID)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	data := make([]url.RedirectRule, len(rules))
+	for i := range rules {
+		data[i] = rules[i].ToResponse()
+	}
+
+	response := url.GetRedirectRulesResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Redirect rules retrieved successfully",
+		},
+		Data: data,
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Create redirect rule
+// @Description Add a rule sending visitors on a given device, operating system or in a given country to a different destination.
+// @Description Visitors matching no rule go to the URL's original destination. Country rules need a GeoIP database to be configured.
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param request body url.CreateRedirectRuleRequest true "Redirect rule details"
+// @Success 201 {object} url.RedirectRuleResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/rules [post]
+func CreateRedirectRule(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return
+	}
+
+	var req url.CreateRedirectRuleRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	ruleService := getRedirectRuleService()
+	rule, err := ruleService.CreateRule(uint(id), userID, req)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	response := url.RedirectRuleResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Redirect rule created successfully",
+		},
+		Data: rule.ToResponse(),
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Update redirect rule
+// @Description Update a URL's redirect rule
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param rule_id path int true "Redirect rule ID"
+// @Param request body url.UpdateRedirectRuleRequest true "Redirect rule update details"
+// @Success 200 {object} url.RedirectRuleResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/rules/{rule_id} [put]
+func UpdateRedirectRule(c *gin.Context) {
+	id, ruleID, ok := parseRedirectRuleIDs(c)
+	if !ok {
+		return
+	}
+
+	var req url.UpdateRedirectRuleRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	ruleService := getRedirectRuleService()
+	rule, err := ruleService.UpdateRule(id, ruleID, userID, req)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	response := url.RedirectRuleResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Redirect rule updated successfully",
+		},
+		Data: rule.ToResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Delete redirect rule
+// @Description Delete a URL's redirect rule
+// @Tags urls
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param rule_id path int true "Redirect rule ID"
+// @Success 200 {object} url.DeleteRedirectRuleResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/rules/{rule_id} [delete]
+func DeleteRedirectRule(c *gin.Context) {
+	id, ruleID, ok := parseRedirectRuleIDs(c)
+	if !ok {
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	ruleService := getRedirectRuleService()
+	if err := ruleService.DeleteRule(id, ruleID, userID); err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	response := url.DeleteRedirectRuleResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Redirect rule deleted successfully",
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseRedirectRuleIDs parses the URL and rule IDs from the path, writing an error response if either is invalid
+func parseRedirectRuleIDs(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return 0, 0, false
+	}
+
+	ruleID, err := strconv.ParseUint(c.Param("rule_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid redirect rule ID"))
+		return 0, 0, false
+	}
+
+	return uint(id), uint(ruleID), true
+}
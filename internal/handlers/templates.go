@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unlockPageTemplate is the form shown in place of a redirect for password-protected links
+var unlockPageTemplate = template.Must(template.New("unlock").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Protected link</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f4f4f5; display: flex; align-items: center; justify-content: center; min-height: 100vh; margin: 0; }
+form { background: #fff; padding: 2rem; border-radius: 8px; box-shadow: 0 1px 3px rgba(0,0,0,.1); width: 100%; max-width: 320px; }
+h1 { font-size: 1.25rem; margin: 0 0 1rem; }
+input, button { width: 100%; box-sizing: border-box; padding: .6rem; font-size: 1rem; margin-top: .5rem; }
+button { background: #18181b; color: #fff; border: 0; border-radius: 4px; cursor: pointer; }
+.error { color: #dc2626; margin: 0 0 .5rem; }
+</style>
+</head>
+<body>
+<form method="post" action="">
+<h1>This link is password protected</h1>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<label for="password">Password</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required autofocus>
+<button type="submit">Continue</button>
+</form>
+</body>
+</html>
+`))
+
+// renderUnlockPage writes the password form for a protected link
+func renderUnlockPage(c *gin.Context, statusCode int, errorMessage string) {
+	var page bytes.Buffer
+	if err := unlockPageTemplate.Execute(&page, gin.H{"Error": errorMessage}); err != nil {
+		c.String(http.StatusInternalServerError, "Failed to render page")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(statusCode, "text/html; charset=utf-8", page.Bytes())
+}
+
+// fallbackPageTemplate is the server-wide page shown to browsers visiting an unavailable
+// link that has no fallback URL. Nil until configured, in which case JSON errors are returned.
+var fallbackPageTemplate *template.Template
+
+// SetFallbackPage loads the branded page shown for unavailable links. The file is an
+// html/template executed with .ShortCode, .Status and .Message.
+func SetFallbackPage(path string) error {
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		return err
+	}
+	fallbackPageTemplate = tmpl
+	return nil
+}
+
+// renderFallbackPage writes the branded page for an unavailable link, reporting false
+// if no page is configured or the client isn't a browser
+func renderFallbackPage(c *gin.Context, statusCode int, message string) bool {
+	if fallbackPageTemplate == nil || !strings.Contains(c.GetHeader("Accept"), "text/html") {
+		return false
+	}
+
+	var page bytes.Buffer
+	data := gin.H{
+		"ShortCode": c.Param("short_code"),
+		"Status":    statusCode,
+		"Message":   message,
+	}
+	if err := fallbackPageTemplate.Execute(&page, data); err != nil {
+		return false
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(statusCode, "text/html; charset=utf-8", page.Bytes())
+	return true
+}
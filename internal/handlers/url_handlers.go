@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/tinwritescode/myapp/internal/dto/common"
@@ -16,6 +17,10 @@ func getURLService() service.URLService {
 	return service.GetURLService()
 }
 
+func getClickService() service.ClickService {
+	return service.GetClickService()
+}
+
 // @Summary Create URL
 // @Description Create a new short URL
 // @Tags urls
@@ -39,7 +44,7 @@ func CreateURL(c *gin.Context) {
 	}
 
 	urlService := getURLService()
-	createdURL, err := urlService.CreateURL(req.OriginalURL, req.ShortCode, userID, req.ExpiresAt)
+	createdURL, err := urlService.CreateURL(req, userID)
 	if err != nil {
 		handleURLError(c, err)
 		return
@@ -80,7 +85,7 @@ func CreatePublicURL(c *gin.Context) {
 
 	// Create URL with user ID if authenticated, otherwise public
 	urlService := getURLService()
-	createdURL, err := urlService.CreateURL(req.OriginalURL, req.ShortCode, userID, req.ExpiresAt)
+	createdURL, err := urlService.CreateURL(req, userID)
 	if err != nil {
 		handleURLError(c, err)
 		return
@@ -229,7 +234,7 @@ func UpdateURL(c *gin.Context) {
 	}
 
 	urlService := getURLService()
-	updatedURL, err := urlService.UpdateURL(uint(id), userID, req.OriginalURL, req.ExpiresAt, req.IsActive)
+	updatedURL, err := urlService.UpdateURL(uint(id), userID, req)
 	if err != nil {
 		handleURLError(c, err)
 		return
@@ -287,48 +292,120 @@ func DeleteURL(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
-// @Summary Redirect to original URL
-// @Description Redirect to the original URL using short code
+// @Summary Get URL statistics
+// @Description Get statistics for a specific URL, including clicks per variant for URLs rotating between destinations
 // @Tags urls
-// @Param short_code path string true "Short code"
-// @Success 302 {string} string "Redirect to original URL"
+// @Accept json
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param limit query int false "Number of recent clicks to return" default(10)
+// @Param top query int false "Number of values per breakdown" default(5)
+// @Param include_bots query bool false "Include crawler and link-preview traffic" default(false)
+// @Success 200 {object} url.URLStatsResponse
+// @Failure 400 {object} common.ErrorResponse
 // @Failure 404 {object} common.ErrorResponse
-// @Router /{short_code} [get]
-func RedirectURL(c *gin.Context) {
-	shortCode := c.Param("short_code")
-	if shortCode == "" {
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Short code is required"))
+// @Router /urls/{id}/stats [get]
+func GetURLStats(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return
+	}
+
+	var req url.GetURLStatsRequest
+	if !middleware.BindQuery(c, &req) {
 		return
 	}
 
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
 	urlService := getURLService()
-	urlData, err := urlService.GetURLByShortCode(shortCode)
+	urlData, err := urlService.GetURLStats(uint(id), userID)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	clickService := getClickService()
+	clicks, err := clickService.GetRecentClicks(urlData.ID, req.Limit, req.IncludeBots)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	breakdowns, err := clickService.GetClickBreakdowns(urlData.ID, req.Top, req.IncludeBots)
 	if err != nil {
 		handleURLError(c, err)
 		return
 	}
 
-	// Increment click count
-	if err := urlService.IncrementClickCount(shortCode); err != nil {
-		// Log error but don't fail the redirect
-		// You might want to use a proper logger here
+	recentClicks := make([]url.ClickEvent, len(clicks))
+	for i, click := range clicks {
+		recentClicks[i] = click.ToResponse()
+	}
+
+	var variants []url.VariantStats
+	if len(urlData.Variants) > 0 {
+		variantClicks, err := clickService.GetVariantClicks(urlData.ID, req.IncludeBots)
+		if err != nil {
+			handleURLError(c, err)
+			return
+		}
+
+		variants = make([]url.VariantStats, len(urlData.Variants))
+		for i := range urlData.Variants {
+			variants[i] = url.VariantStats{
+				URLVariant: urlData.Variants[i].ToResponse(),
+				Clicks:     variantClicks[urlData.Variants[i].ID],
+			}
+		}
+	}
+
+	response := url.URLStatsResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "URL statistics retrieved successfully",
+		},
+		Data: url.URLStats{
+			URLResponse:  urlData.ToResponse(),
+			RecentClicks: recentClicks,
+			Breakdowns: url.ClickBreakdowns{
+				Browsers:         toBreakdownItems(breakdowns.Browsers),
+				OperatingSystems: toBreakdownItems(breakdowns.OperatingSystems),
+				Devices:          toBreakdownItems(breakdowns.Devices),
+				Referrers:        toBreakdownItems(breakdowns.Referrers),
+				Countries:        toBreakdownItems(breakdowns.Countries),
+				Cities:           toBreakdownItems(breakdowns.Cities),
+				Sources:          toBreakdownItems(breakdowns.Sources),
+			},
+			Variants: variants,
+		},
 	}
 
-	// Redirect to original URL
-	c.Redirect(http.StatusFound, urlData.OriginalURL)
+	c.JSON(http.StatusOK, response)
 }
 
-// @Summary Get URL statistics
-// @Description Get statistics for a specific URL
+// @Summary Get URL click time series
+// @Description Get clicks for a specific URL aggregated into hourly, daily or weekly buckets
 // @Tags urls
 // @Accept json
 // @Produce json
 // @Param id path int true "URL ID"
-// @Success 200 {object} url.URLStatsResponse
+// @Param from query string false "Start of the range (RFC3339), defaults to a window based on interval"
+// @Param to query string false "End of the range (RFC3339), defaults to now"
+// @Param interval query string false "Bucket size" Enums(hour, day, week) default(day)
+// @Param timezone query string false "IANA time zone used to align buckets" default(UTC)
+// @Param include_bots query bool false "Include crawler and link-preview traffic" default(false)
+// @Success 200 {object} url.URLTimeSeriesResponse
 // @Failure 400 {object} common.ErrorResponse
 // @Failure 404 {object} common.ErrorResponse
-// @Router /urls/{id}/stats [get]
-func GetURLStats(c *gin.Context) {
+// @Router /urls/{id}/stats/timeseries [get]
+func GetURLTimeSeries(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
@@ -336,6 +413,26 @@ func GetURLStats(c *gin.Context) {
 		return
 	}
 
+	var req url.GetURLTimeSeriesRequest
+	if !middleware.BindQuery(c, &req) {
+		return
+	}
+
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid timezone"))
+		return
+	}
+
+	to := time.Now()
+	if req.To != nil {
+		to = *req.To
+	}
+	from := to.Add(-utils.DefaultTimeSeriesRange(req.Interval))
+	if req.From != nil {
+		from = *req.From
+	}
+
 	// Get user ID from context if authenticated
 	var userID *uint
 	if uid, exists := middleware.GetUserID(c); exists {
@@ -349,22 +446,65 @@ func GetURLStats(c *gin.Context) {
 		return
 	}
 
-	response := url.URLStatsResponse{
+	buckets, err := getClickService().GetClickTimeSeries(urlData.ID, from, to, req.Interval, loc, req.IncludeBots)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	var total int64
+	points := make([]url.TimeSeriesPoint, len(buckets))
+	for i, bucket := range buckets {
+		points[i] = url.TimeSeriesPoint{
+			Timestamp: bucket.Start,
+			Clicks:    bucket.Clicks,
+		}
+		total += bucket.Clicks
+	}
+
+	response := url.URLTimeSeriesResponse{
 		BaseResponse: common.BaseResponse{
 			Success: true,
-			Message: "URL statistics retrieved successfully",
+			Message: "URL time series retrieved successfully",
 		},
-		Data: url.URLStats{
-			URLResponse: urlData.ToResponse(),
-			// RecentClicks would be populated from a separate table in a real implementation
+		Data: url.URLTimeSeries{
+			URLID:    urlData.ID,
+			Interval: req.Interval,
+			Timezone: loc.String(),
+			From:     from.In(loc),
+			To:       to.In(loc),
+			Total:    total,
+			Points:   points,
 		},
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// toBreakdownItems maps service breakdown counts to response items
+func toBreakdownItems(counts []service.BreakdownCount) []url.BreakdownItem {
+	items := make([]url.BreakdownItem, len(counts))
+	for i, count := range counts {
+		items[i] = url.BreakdownItem{
+			Value:  count.Value,
+			Clicks: count.Clicks,
+		}
+	}
+	return items
+}
+
 // handleURLError handles URL-specific errors
 func handleURLError(c *gin.Context, err error) {
+	statusCode := urlErrorStatus(err)
+	if appErr, ok := err.(*common.AppError); ok {
+		c.JSON(statusCode, common.NewErrorResponseWithCode(appErr.Code, appErr.Message))
+	} else {
+		c.JSON(statusCode, common.NewErrorResponse(err.Error()))
+	}
+}
+
+// urlErrorStatus returns the HTTP status for a URL-specific error
+func urlErrorStatus(err error) int {
 	statusCode := http.StatusInternalServerError
 	if appErr, ok := err.(*common.AppError); ok {
 		switch appErr.Code {
@@ -372,10 +512,12 @@ func handleURLError(c *gin.Context, err error) {
 			statusCode = http.StatusBadRequest
 		case common.SHORT_CODE_ALREADY_EXISTS:
 			statusCode = http.StatusConflict
-		case common.URL_NOT_FOUND:
+		case common.URL_NOT_FOUND, common.NOT_FOUND:
 			statusCode = http.StatusNotFound
 		case common.URL_EXPIRED:
 			statusCode = http.StatusGone
+		case common.URL_NOT_YET_ACTIVE:
+			statusCode = http.StatusForbidden
 		case common.UNAUTHORIZED:
 			statusCode = http.StatusUnauthorized
 		case common.FORBIDDEN:
@@ -383,8 +525,6 @@ func handleURLError(c *gin.Context, err error) {
 		case common.INTERNAL_SERVER_ERROR:
 			statusCode = http.StatusInternalServerError
 		}
-		c.JSON(statusCode, common.NewErrorResponseWithCode(appErr.Code, appErr.Message))
-	} else {
-		c.JSON(statusCode, common.NewErrorResponse(err.Error()))
 	}
+	return statusCode
 }
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/middleware"
+	"github.com/tinwritescode/myapp/internal/service"
+)
+
+func getURLVariantService() service.URLVariantService {
+	return service.GetURLVariantService()
+}
+
+// @Summary Get URL variants
+// @Description Get the destinations a URL rotates between
+// @Tags urls
+// @Produce json
+// @Param id path int true "URL ID"
+// @Success 200 {object} url.GetURLVariantsResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/variants [get]
+func GetURLVariants(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	variantService := getURLVariantService()
+	variants, err := variantService.GetVariants(uint(id), userID)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	data := make([]url.URLVariant, len(variants))
+	for i := range variants {
+		data[i] = variants[i].ToResponse()
+	}
+
+	response := url.GetURLVariantsResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Variants retrieved successfully",
+		},
+		Data: data,
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Create URL variant
+// @Description Add a destination for the URL to rotate between. Each visit picks a variant in proportion to its weight,
+// @Description replacing the original destination; redirect rules still take precedence.
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param request body url.CreateURLVariantRequest true "Variant details"
+// @Success 201 {object} url.URLVariantResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/variants [post]
+func CreateURLVariant(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return
+	}
+
+	var req url.CreateURLVariantRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	variantService := getURLVariantService()
+	variant, err := variantService.CreateVariant(uint(id), userID, req)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	response := url.URLVariantResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Variant created successfully",
+		},
+		Data: variant.ToResponse(),
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary Update URL variant
+// @Description Update a URL variant, e.g. set its weight to 0 to pause it
+// @Tags urls
+// @Accept json
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param variant_id path int true "Variant ID"
+// @Param request body url.UpdateURLVariantRequest true "Variant update details"
+// @Success 200 {object} url.URLVariantResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/variants/{variant_id} [put]
+func UpdateURLVariant(c *gin.Context) {
+	id, variantID, ok := parseURLVariantIDs(c)
+	if !ok {
+		return
+	}
+
+	var req url.UpdateURLVariantRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	variantService := getURLVariantService()
+	variant, err := variantService.UpdateVariant(id, variantID, userID, req)
+	if err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	response := url.URLVariantResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Variant updated successfully",
+		},
+		Data: variant.ToResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Delete URL variant
+// @Description Delete a URL variant; clicks it already served stay in the statistics
+// @Tags urls
+// @Produce json
+// @Param id path int true "URL ID"
+// @Param variant_id path int true "Variant ID"
+// @Success 200 {object} url.DeleteURLVariantResponse
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /urls/{id}/variants/{variant_id} [delete]
+func DeleteURLVariant(c *gin.Context) {
+	id, variantID, ok := parseURLVariantIDs(c)
+	if !ok {
+		return
+	}
+
+	// Get user ID from context if authenticated
+	var userID *uint
+	if uid, exists := middleware.GetUserID(c); exists {
+		userID = &uid
+	}
+
+	variantService := getURLVariantService()
+	if err := variantService.DeleteVariant(id, variantID, userID); err != nil {
+		handleURLError(c, err)
+		return
+	}
+
+	response := url.DeleteURLVariantResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Variant deleted successfully",
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseURLVariantIDs parses the URL and variant IDs from the path, writing an error response if either is invalid
+func parseURLVariantIDs(c *gin.Context) (uint, uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid URL ID"))
+		return 0, 0, false
+	}
+
+	variantID, err := strconv.ParseUint(c.Param("variant_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewErrorResponse("Invalid variant ID"))
+		return 0, 0, false
+	}
+
+	return uint(id), uint(variantID), true
+}
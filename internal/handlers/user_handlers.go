@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/tinwritescode/myapp/internal/dto/auth"
 	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/dto/user"
 	"github.com/tinwritescode/myapp/internal/middleware"
 	"github.com/tinwritescode/myapp/internal/service"
 )
@@ -171,3 +172,95 @@ func RefreshToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// @Summary Get user settings
+// @Description Get the current user's link settings
+// @Tags users
+// @Produce json
+// @Success 200 {object} user.GetUserSettingsResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /users/me/settings [get]
+func GetUserSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	userService := getUserService()
+	currentUser, err := userService.GetUserByID(userID)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+
+	response := user.GetUserSettingsResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Settings retrieved successfully",
+		},
+		Data: currentUser.ToSettingsResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Update user settings
+// @Description Update the current user's link settings, such as the default fallback URL for unavailable links
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body user.UpdateUserSettingsRequest true "Settings to update"
+// @Success 200 {object} user.UpdateUserSettingsResponse
+// @Failure 400 {object} common.ValidationErrorResponse
+// @Failure 401 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Router /users/me/settings [put]
+func UpdateUserSettings(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+		return
+	}
+
+	var req user.UpdateUserSettingsRequest
+	if !middleware.BindJSON(c, &req) {
+		return
+	}
+
+	userService := getUserService()
+	updatedUser, err := userService.UpdateSettings(userID, req)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+
+	response := user.UpdateUserSettingsResponse{
+		BaseResponse: common.BaseResponse{
+			Success: true,
+			Message: "Settings updated successfully",
+		},
+		Data: updatedUser.ToSettingsResponse(),
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// handleUserError handles user-specific errors
+func handleUserError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	if appErr, ok := err.(*common.AppError); ok {
+		switch appErr.Code {
+		case common.VALIDATION_ERROR:
+			statusCode = http.StatusBadRequest
+		case common.USER_NOT_FOUND:
+			statusCode = http.StatusNotFound
+		case common.INTERNAL_SERVER_ERROR:
+			statusCode = http.StatusInternalServerError
+		}
+		c.JSON(statusCode, common.NewErrorResponseWithCode(appErr.Code, appErr.Message))
+	} else {
+		c.JSON(statusCode, common.NewErrorResponse(err.Error()))
+	}
+}
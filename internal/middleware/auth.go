@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/service"
 )
 
 // Claims represents the JWT claims structure
@@ -98,6 +99,28 @@ func OptionalAuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// AdminMiddleware only lets admins through. It must run after AuthMiddleware. Admin rights
+// are read from the database on each request so revoking them takes effect immediately.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := GetUserID(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, common.NewErrorResponseWithCode(common.UNAUTHORIZED, "User not authenticated"))
+			c.Abort()
+			return
+		}
+
+		user, err := service.GetUserService().GetUserByID(userID)
+		if err != nil || !user.IsAdmin {
+			c.JSON(http.StatusForbidden, common.NewErrorResponseWithCode(common.FORBIDDEN, "Admin access required"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // validateToken parses and validates a JWT token
 func validateToken(tokenString string) (*Claims, error) {
 	// Parse token
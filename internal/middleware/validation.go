@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/tinwritescode/myapp/internal/dto/common"
 )
@@ -169,6 +170,15 @@ func BindJSON(c *gin.Context, req interface{}) bool {
 	return true
 }
 
+// ValidateStruct validates a value that wasn't bound from the request, such as one item of a
+// batch, returning nil if it is valid
+func ValidateStruct(obj interface{}) []common.ValidationError {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return formatValidationErrors(err)
+	}
+	return nil
+}
+
 // BindQuery validates and binds query parameters to the provided struct
 // Returns true if binding was successful, false if there was an error
 func BindQuery(c *gin.Context, req interface{}) bool {
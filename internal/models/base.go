@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/tinwritescode/myapp/internal/dto/url"
@@ -22,6 +23,19 @@ type User struct {
 	Password string `gorm:"not null" json:"-"`
 	FullName string `json:"full_name"`
 	IsActive bool   `gorm:"default:true" json:"is_active"`
+	// IsAdmin lets the user manage server-wide settings such as reserved short codes
+	IsAdmin bool `gorm:"not null;default:false" json:"is_admin"`
+
+	// DefaultFallbackURL is where visitors of the user's unavailable links are sent
+	// when a link doesn't set its own fallback
+	DefaultFallbackURL string `json:"default_fallback_url,omitempty"`
+}
+
+// ToSettingsResponse converts User model to UserSettings DTO
+func (u *User) ToSettingsResponse() user.UserSettings {
+	return user.UserSettings{
+		DefaultFallbackURL: u.DefaultFallbackURL,
+	}
 }
 
 // ToResponse converts User model to UserResponse DTO
@@ -32,6 +46,7 @@ func (u *User) ToResponse() user.UserResponse {
 		Username:  u.Username,
 		FullName:  u.FullName,
 		IsActive:  u.IsActive,
+		IsAdmin:   u.IsAdmin,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
@@ -48,25 +63,128 @@ type Account struct {
 
 type URL struct {
 	BaseModel
-	OriginalURL string     `gorm:"not null" json:"original_url"`
-	ShortCode   string     `gorm:"uniqueIndex;not null" json:"short_code"`
-	UserID      *uint      `gorm:"index" json:"user_id,omitempty"`
-	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
-	ClickCount  int64      `gorm:"default:0" json:"click_count"`
-	IsActive    bool       `gorm:"default:true" json:"is_active"`
+	OriginalURL   string     `gorm:"not null" json:"original_url"`
+	ShortCode     string     `gorm:"not null;uniqueIndex:idx_urls_short_code_domain,priority:1" json:"short_code"`
+	DomainID      uint       `gorm:"not null;default:0;uniqueIndex:idx_urls_short_code_domain,priority:2" json:"domain_id,omitempty"`
+	UserID        *uint      `gorm:"index" json:"user_id,omitempty"`
+	ActivatesAt   *time.Time `json:"activates_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	ClickCount    int64      `gorm:"default:0" json:"click_count"`
+	BotClickCount int64      `gorm:"default:0" json:"bot_click_count"`
+	UniqueClicks  int64      `gorm:"default:0" json:"unique_clicks"`
+	IsActive      bool       `gorm:"default:true" json:"is_active"`
+
+	// RedirectStatus is the HTTP status used when redirecting: 301, 302, 307 or 308, or 0 to
+	// follow the server default
+	RedirectStatus int `gorm:"not null;default:0" json:"redirect_status,omitempty"`
+	// PasswordHash is a bcrypt hash; protected links show an unlock form instead of redirecting
+	PasswordHash string `json:"-"`
+	// MaxClicks deactivates the link once ClickCount reaches it; nil means unlimited
+	MaxClicks *int64 `json:"max_clicks,omitempty"`
+	// FallbackURL is where visitors are sent while the link is unavailable: not active yet,
+	// expired, disabled or out of clicks
+	FallbackURL string `json:"fallback_url,omitempty"`
+	// ForwardQuery appends the query string of the short link to the destination
+	ForwardQuery bool `gorm:"not null;default:false" json:"forward_query"`
+	// ForwardPath appends whatever follows the short code in the path to the destination
+	ForwardPath bool `gorm:"not null;default:false" json:"forward_path"`
+
+	// RedirectRules override the destination for matching visitors, ordered by position
+	RedirectRules []RedirectRule `gorm:"foreignKey:URLID;constraint:OnDelete:CASCADE" json:"redirect_rules,omitempty"`
+	// Variants replace the original destination with a weighted rotation when present
+	Variants []URLVariant `gorm:"foreignKey:URLID;constraint:OnDelete:CASCADE" json:"variants,omitempty"`
+
+	// UTM parameters are added to every destination, each overriding the campaign template's
+	UTM                UTMParams         `gorm:"embedded;embeddedPrefix:utm_" json:"utm"`
+	CampaignTemplateID *uint             `gorm:"index" json:"campaign_template_id,omitempty"`
+	CampaignTemplate   *CampaignTemplate `gorm:"foreignKey:CampaignTemplateID;constraint:OnDelete:SET NULL" json:"campaign_template,omitempty"`
+
+	// Domain is the custom domain the link is served from. It is not a foreign key as
+	// links on the server's own domain have DomainID 0.
+	Domain *Domain `gorm:"foreignKey:DomainID;-:migration" json:"domain,omitempty"`
+}
+
+// Base URL of short links on the server's own domain - will be set from config
+var shortURLBase string
+
+// SetShortURLBase sets the scheme and host short links on the server's own domain are served from
+func SetShortURLBase(baseURL string) {
+	shortURLBase = strings.TrimSuffix(baseURL, "/")
+}
+
+// ShortURLBase returns the scheme and host short links on the server's own domain are served from
+func ShortURLBase() string {
+	return shortURLBase
+}
+
+// ShortURL returns the full short link, on the URL's custom domain if it has one. The
+// domain must be preloaded.
+func (u *URL) ShortURL() string {
+	if u.DomainID != 0 && u.Domain != nil {
+		return "https://" + u.Domain.Hostname + "/" + u.ShortCode
+	}
+	if shortURLBase == "" {
+		return ""
+	}
+	return shortURLBase + "/" + u.ShortCode
+}
+
+// HasPassword reports whether the URL is password protected
+func (u *URL) HasPassword() bool {
+	return u.PasswordHash != ""
+}
+
+// HasReachedClickLimit reports whether a click-limited URL has used up all of its clicks
+func (u *URL) HasReachedClickLimit() bool {
+	return u.MaxClicks != nil && u.ClickCount >= *u.MaxClicks
+}
+
+// EffectiveUTM returns the UTM parameters added to the URL's destination: the campaign
+// template's, overridden by any the link sets itself. The template must be preloaded.
+func (u *URL) EffectiveUTM() UTMParams {
+	var params UTMParams
+	if u.CampaignTemplate != nil {
+		params = u.CampaignTemplate.UTM
+	}
+	return params.Override(u.UTM)
 }
 
 // ToResponse converts URL model to URLResponse DTO
 func (u *URL) ToResponse() url.URLResponse {
+	rules := make([]url.RedirectRule, len(u.RedirectRules))
+	for i := range u.RedirectRules {
+		rules[i] = u.RedirectRules[i].ToResponse()
+	}
+
+	variants := make([]url.URLVariant, len(u.Variants))
+	for i := range u.Variants {
+		variants[i] = u.Variants[i].ToResponse()
+	}
+
 	return url.URLResponse{
-		ID:          u.ID,
-		OriginalURL: u.OriginalURL,
-		ShortCode:   u.ShortCode,
-		UserID:      u.UserID,
-		ExpiresAt:   u.ExpiresAt,
-		ClickCount:  u.ClickCount,
-		IsActive:    u.IsActive,
-		CreatedAt:   u.CreatedAt,
-		UpdatedAt:   u.UpdatedAt,
+		ID:                 u.ID,
+		OriginalURL:        u.OriginalURL,
+		ShortCode:          u.ShortCode,
+		ShortURL:           u.ShortURL(),
+		DomainID:           u.DomainID,
+		UserID:             u.UserID,
+		ActivatesAt:        u.ActivatesAt,
+		ExpiresAt:          u.ExpiresAt,
+		ClickCount:         u.ClickCount,
+		BotClickCount:      u.BotClickCount,
+		UniqueClicks:       u.UniqueClicks,
+		RedirectStatus:     u.RedirectStatus,
+		HasPassword:        u.HasPassword(),
+		MaxClicks:          u.MaxClicks,
+		FallbackURL:        u.FallbackURL,
+		ForwardQuery:       u.ForwardQuery,
+		ForwardPath:        u.ForwardPath,
+		RedirectRules:      rules,
+		Variants:           variants,
+		UTM:                u.UTM.ToResponse(),
+		CampaignTemplateID: u.CampaignTemplateID,
+		IsActive:           u.IsActive,
+		CreatedAt:          u.CreatedAt,
+		UpdatedAt:          u.UpdatedAt,
 	}
 }
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/campaign"
+	urlDTO "github.com/tinwritescode/myapp/internal/dto/url"
+)
+
+// UTMParams are the campaign tracking parameters added to a link's destination
+type UTMParams struct {
+	Source   string `gorm:"size:100" json:"source,omitempty"`
+	Medium   string `gorm:"size:100" json:"medium,omitempty"`
+	Campaign string `gorm:"size:100" json:"campaign,omitempty"`
+	Term     string `gorm:"size:100" json:"term,omitempty"`
+	Content  string `gorm:"size:100" json:"content,omitempty"`
+}
+
+// NewUTMParams converts UTM parameters from a request
+func NewUTMParams(params urlDTO.UTMParams) UTMParams {
+	return UTMParams{
+		Source:   params.Source,
+		Medium:   params.Medium,
+		Campaign: params.Campaign,
+		Term:     params.Term,
+		Content:  params.Content,
+	}
+}
+
+// Override returns p with every non-empty parameter of other taking precedence
+func (p UTMParams) Override(other UTMParams) UTMParams {
+	if other.Source != "" {
+		p.Source = other.Source
+	}
+	if other.Medium != "" {
+		p.Medium = other.Medium
+	}
+	if other.Campaign != "" {
+		p.Campaign = other.Campaign
+	}
+	if other.Term != "" {
+		p.Term = other.Term
+	}
+	if other.Content != "" {
+		p.Content = other.Content
+	}
+	return p
+}
+
+// Values returns the parameters as utm_* query values, omitting empty ones
+func (p UTMParams) Values() url.Values {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"utm_source":   p.Source,
+		"utm_medium":   p.Medium,
+		"utm_campaign": p.Campaign,
+		"utm_term":     p.Term,
+		"utm_content":  p.Content,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
+
+// ToResponse converts UTMParams model to UTMParams DTO
+func (p UTMParams) ToResponse() urlDTO.UTMParams {
+	return urlDTO.UTMParams{
+		Source:   p.Source,
+		Medium:   p.Medium,
+		Campaign: p.Campaign,
+		Term:     p.Term,
+		Content:  p.Content,
+	}
+}
+
+// CampaignTemplate is a reusable set of UTM parameters that a user's links can share.
+// Deleting a template detaches it from its links.
+type CampaignTemplate struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_campaign_templates_user_name,priority:1" json:"user_id"`
+	Name      string    `gorm:"size:100;not null;uniqueIndex:idx_campaign_templates_user_name,priority:2" json:"name"`
+	UTM       UTMParams `gorm:"embedded;embeddedPrefix:utm_" json:"utm"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for CampaignTemplate
+func (CampaignTemplate) TableName() string {
+	return "campaign_templates"
+}
+
+// ToResponse converts CampaignTemplate model to CampaignTemplate DTO
+func (t *CampaignTemplate) ToResponse() campaign.CampaignTemplate {
+	return campaign.CampaignTemplate{
+		ID:        t.ID,
+		Name:      t.Name,
+		UTM:       t.UTM.ToResponse(),
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/url"
+)
+
+// ClickSourceQR marks clicks made by scanning the link's QR code
+const ClickSourceQR = "qr"
+
+// ClickEvent represents a single redirect through a short URL
+type ClickEvent struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	URLID     uint      `gorm:"not null;index:idx_click_events_url_clicked_at,priority:1" json:"url_id"`
+	ClickedAt time.Time `gorm:"not null;index:idx_click_events_url_clicked_at,priority:2" json:"clicked_at"`
+	IPAddress string    `gorm:"size:45" json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Referer   *string   `json:"referer,omitempty"`
+
+	// Parsed from the User-Agent and Referer headers at redirect time
+	Browser       string `gorm:"size:50" json:"browser"`
+	OS            string `gorm:"size:50" json:"os"`
+	DeviceType    string `gorm:"size:20" json:"device_type"`
+	RefererDomain string `gorm:"size:255" json:"referer_domain"`
+	IsBot         bool   `gorm:"default:false;index" json:"is_bot"`
+
+	// Resolved from the client IP when a GeoIP database is configured
+	Country string `gorm:"size:2;index" json:"country"`
+	City    string `gorm:"size:100" json:"city"`
+
+	// Source is how the visitor reached the link: ClickSourceQR for QR code scans, empty otherwise
+	Source string `gorm:"size:20;not null;default:''" json:"source"`
+
+	// VariantID is the destination variant served, for URLs rotating between several
+	VariantID *uint `gorm:"index" json:"variant_id,omitempty"`
+
+	// VisitorHash anonymously identifies the visitor for the day of the click
+	VisitorHash string `gorm:"size:64;index" json:"-"`
+
+	// Counted is set when the click was already added to the URL's click count at
+	// redirect time, as click-limited links must be, so batch recording skips it
+	Counted bool `gorm:"-" json:"-"`
+
+	// Foreign key relationship
+	URL URL `gorm:"foreignKey:URLID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName returns the table name for ClickEvent
+func (ClickEvent) TableName() string {
+	return "click_events"
+}
+
+// ToResponse converts ClickEvent model to ClickEvent DTO
+func (e *ClickEvent) ToResponse() url.ClickEvent {
+	return url.ClickEvent{
+		ID:            e.ID,
+		URLID:         e.URLID,
+		IPAddress:     e.IPAddress,
+		UserAgent:     e.UserAgent,
+		Referer:       e.Referer,
+		Browser:       e.Browser,
+		OS:            e.OS,
+		DeviceType:    e.DeviceType,
+		RefererDomain: e.RefererDomain,
+		IsBot:         e.IsBot,
+		Country:       e.Country,
+		City:          e.City,
+		VariantID:     e.VariantID,
+		Source:        e.Source,
+		ClickedAt:     e.ClickedAt,
+	}
+}
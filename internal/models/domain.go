@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/domain"
+)
+
+// DomainVerificationPrefix is the label under which a domain's verification TXT record is published
+const DomainVerificationPrefix = "_myapp-verification"
+
+// Domain is a user's own hostname that their short links can be served from. Links can only
+// be created on a domain once its owner has proven control of it with a DNS TXT record.
+type Domain struct {
+	ID                uint       `gorm:"primaryKey" json:"id"`
+	UserID            uint       `gorm:"not null;uniqueIndex:idx_domains_user_hostname,priority:1" json:"user_id"`
+	Hostname          string     `gorm:"size:253;not null;uniqueIndex:idx_domains_user_hostname,priority:2;index" json:"hostname"`
+	VerificationToken string     `gorm:"size:64;not null" json:"-"`
+	VerifiedAt        *time.Time `json:"verified_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for Domain
+func (Domain) TableName() string {
+	return "domains"
+}
+
+// IsVerified reports whether the owner has proven control of the domain
+func (d *Domain) IsVerified() bool {
+	return d.VerifiedAt != nil
+}
+
+// VerificationRecordName returns the DNS name the verification TXT record is looked up at
+func (d *Domain) VerificationRecordName() string {
+	return DomainVerificationPrefix + "." + d.Hostname
+}
+
+// ToResponse converts Domain model to Domain DTO
+func (d *Domain) ToResponse() domain.Domain {
+	return domain.Domain{
+		ID:         d.ID,
+		Hostname:   d.Hostname,
+		Verified:   d.IsVerified(),
+		VerifiedAt: d.VerifiedAt,
+		VerificationRecord: domain.VerificationRecord{
+			Type:  "TXT",
+			Name:  d.VerificationRecordName(),
+			Value: d.VerificationToken,
+		},
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/url"
+)
+
+// Redirect rule types
+const (
+	// RuleTypeDevice matches the visitor's device class: desktop, mobile or tablet
+	RuleTypeDevice = "device"
+	// RuleTypeOS matches the visitor's operating system family, e.g. iOS or Android
+	RuleTypeOS = "os"
+	// RuleTypeCountry matches the visitor's ISO 3166-1 alpha-2 country code, e.g. DE
+	RuleTypeCountry = "country"
+)
+
+// RedirectRule sends visitors matching a condition to a different destination than the
+// URL's original one. A URL's rules are evaluated in position order and the first match wins.
+type RedirectRule struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	URLID     uint      `gorm:"not null;index:idx_redirect_rules_url_position,priority:1" json:"url_id"`
+	Position  int       `gorm:"not null;default:0;index:idx_redirect_rules_url_position,priority:2" json:"position"`
+	Type      string    `gorm:"size:20;not null" json:"type"`
+	Value     string    `gorm:"size:50;not null" json:"value"`
+	TargetURL string    `gorm:"not null" json:"target_url"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for RedirectRule
+func (RedirectRule) TableName() string {
+	return "redirect_rules"
+}
+
+// ToResponse converts RedirectRule model to RedirectRule DTO
+func (r *RedirectRule) ToResponse() url.RedirectRule {
+	return url.RedirectRule{
+		ID:        r.ID,
+		Position:  r.Position,
+		Type:      r.Type,
+		Value:     r.Value,
+		TargetURL: r.TargetURL,
+	}
+}
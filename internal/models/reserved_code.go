@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/admin"
+)
+
+// Reserved code types
+const (
+	// ReservedCodeTypeReserved blocks the exact code, ignoring case
+	ReservedCodeTypeReserved = "reserved"
+	// ReservedCodeTypeBlocked blocks every code containing the word, ignoring case
+	ReservedCodeTypeBlocked = "blocked"
+)
+
+// ReservedCode is a short code or word admins have taken out of use, on top of the reserved
+// words and blocklist from config. Codes are stored lowercased.
+type ReservedCode struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Code      string    `gorm:"size:50;not null;uniqueIndex" json:"code"`
+	Type      string    `gorm:"size:20;not null" json:"type"`
+	Reason    string    `gorm:"size:255" json:"reason,omitempty"`
+	CreatedBy uint      `gorm:"not null" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for ReservedCode
+func (ReservedCode) TableName() string {
+	return "reserved_codes"
+}
+
+// ToResponse converts ReservedCode model to ReservedCode DTO
+func (r *ReservedCode) ToResponse() admin.ReservedCode {
+	return admin.ReservedCode{
+		ID:        r.ID,
+		Code:      r.Code,
+		Type:      r.Type,
+		Reason:    r.Reason,
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt,
+	}
+}
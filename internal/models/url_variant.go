@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/dto/url"
+)
+
+// URLVariant is one of several destinations a URL rotates between. Each visit picks a
+// variant with probability proportional to its weight; a weight of 0 pauses the variant.
+type URLVariant struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	URLID     uint      `gorm:"not null;index" json:"url_id"`
+	Label     string    `gorm:"size:50" json:"label"`
+	TargetURL string    `gorm:"not null" json:"target_url"`
+	Weight    int       `gorm:"not null;default:1" json:"weight"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for URLVariant
+func (URLVariant) TableName() string {
+	return "url_variants"
+}
+
+// ToResponse converts URLVariant model to URLVariant DTO
+func (v *URLVariant) ToResponse() url.URLVariant {
+	return url.URLVariant{
+		ID:        v.ID,
+		Label:     v.Label,
+		TargetURL: v.TargetURL,
+		Weight:    v.Weight,
+	}
+}
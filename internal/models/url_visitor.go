@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// URLVisitor records each distinct visitor of a short URL, keyed by an anonymous daily hash
+type URLVisitor struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	URLID       uint      `gorm:"not null;uniqueIndex:idx_url_visitors_url_visitor,priority:1" json:"url_id"`
+	VisitorHash string    `gorm:"size:64;not null;uniqueIndex:idx_url_visitors_url_visitor,priority:2" json:"visitor_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Foreign key relationship
+	URL URL `gorm:"foreignKey:URLID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName returns the table name for URLVisitor
+func (URLVisitor) TableName() string {
+	return "url_visitors"
+}
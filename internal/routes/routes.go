@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -34,13 +36,69 @@ func SetupRoutes(r *gin.Engine) {
 	{
 		// URL routes
 		protected.POST("/urls", handlers.CreateURL)
+		protected.POST("/urls/bulk", handlers.BulkCreateURLs)
 		protected.GET("/urls", handlers.GetURLs)
 		protected.GET("/urls/:id", handlers.GetURLByID)
 		protected.PUT("/urls/:id", handlers.UpdateURL)
 		protected.DELETE("/urls/:id", handlers.DeleteURL)
 		protected.GET("/urls/:id/stats", handlers.GetURLStats)
+		protected.GET("/urls/:id/stats/timeseries", handlers.GetURLTimeSeries)
+		protected.GET("/urls/:id/qr", handlers.GetURLQRCode)
+		protected.GET("/urls/:id/rules", handlers.GetRedirectRules)
+		protected.POST("/urls/:id/rules", handlers.CreateRedirectRule)
+		protected.PUT("/urls/:id/rules/:rule_id", handlers.UpdateRedirectRule)
+		protected.DELETE("/urls/:id/rules/:rule_id", handlers.DeleteRedirectRule)
+		protected.GET("/urls/:id/variants", handlers.GetURLVariants)
+		protected.POST("/urls/:id/variants", handlers.CreateURLVariant)
+		protected.PUT("/urls/:id/variants/:variant_id", handlers.UpdateURLVariant)
+		protected.DELETE("/urls/:id/variants/:variant_id", handlers.DeleteURLVariant)
+
+		// Campaign template routes
+		protected.GET("/campaigns", handlers.GetCampaignTemplates)
+		protected.POST("/campaigns", handlers.CreateCampaignTemplate)
+		protected.GET("/campaigns/:id", handlers.GetCampaignTemplate)
+		protected.PUT("/campaigns/:id", handlers.UpdateCampaignTemplate)
+		protected.DELETE("/campaigns/:id", handlers.DeleteCampaignTemplate)
+
+		// Custom domain routes
+		protected.GET("/domains", handlers.GetDomains)
+		protected.POST("/domains", handlers.CreateDomain)
+		protected.POST("/domains/:id/verify", handlers.VerifyDomain)
+		protected.DELETE("/domains/:id", handlers.DeleteDomain)
+
+		// User routes
+		protected.GET("/users/me/settings", handlers.GetUserSettings)
+		protected.PUT("/users/me/settings", handlers.UpdateUserSettings)
+	}
+
+	// Admin routes
+	admin := r.Group("/api/v1/admin").Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.GET("/reserved-codes", handlers.GetReservedCodes)
+		admin.POST("/reserved-codes", handlers.CreateReservedCode)
+		admin.DELETE("/reserved-codes/:id", handlers.DeleteReservedCode)
+		admin.GET("/metrics/clicks", handlers.GetClickQueueMetrics)
 	}
 
 	// URL redirection route (outside API group for shorter URLs)
 	r.GET("/:short_code", handlers.RedirectURL)
+	r.POST("/:short_code", handlers.UnlockURL)
+	r.GET("/:short_code/*path", handlers.RedirectURL)
+	r.POST("/:short_code/*path", handlers.UnlockURL)
+}
+
+// ReservedShortCodes returns the static first path segments of the registered routes. Gin
+// matches them before the redirect route, so links with these codes could never be reached.
+func ReservedShortCodes(r *gin.Engine) []string {
+	seen := map[string]bool{}
+	var codes []string
+	for _, route := range r.Routes() {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(route.Path, "/"), "/")
+		if segment == "" || strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") || seen[segment] {
+			continue
+		}
+		seen[segment] = true
+		codes = append(codes, segment)
+	}
+	return codes
 }
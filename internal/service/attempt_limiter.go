@@ -0,0 +1,87 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// AttemptLimiter blocks a key after too many failed attempts within a time window,
+// e.g. wrong passwords for a protected link from the same client
+type AttemptLimiter struct {
+	mu          sync.Mutex
+	maxAttempts int
+	window      time.Duration
+	attempts    map[string]*attemptRecord
+	lastSweep   time.Time
+}
+
+type attemptRecord struct {
+	failures    int
+	windowStart time.Time
+}
+
+// NewAttemptLimiter allows maxAttempts failures per key within each window
+func NewAttemptLimiter(maxAttempts int, window time.Duration) *AttemptLimiter {
+	return &AttemptLimiter{
+		maxAttempts: maxAttempts,
+		window:      window,
+		attempts:    make(map[string]*attemptRecord),
+		lastSweep:   time.Now(),
+	}
+}
+
+// Reserve claims an attempt for key, reporting false if its failures are used up. The
+// attempt counts as a failure until Reset, so concurrent attempts can't all pass the check
+// before any of them fails.
+func (l *AttemptLimiter) Reserve(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	record := l.current(key, now)
+	if record == nil {
+		record = &attemptRecord{windowStart: now}
+		l.attempts[key] = record
+	}
+	if record.failures >= l.maxAttempts {
+		return false
+	}
+	record.failures++
+	return true
+}
+
+// Reset clears the failures for key, e.g. after a successful attempt
+func (l *AttemptLimiter) Reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, key)
+}
+
+// current returns the record for key if its window is still open. Must be called with l.mu held.
+func (l *AttemptLimiter) current(key string, now time.Time) *attemptRecord {
+	record, ok := l.attempts[key]
+	if !ok {
+		return nil
+	}
+	if now.Sub(record.windowStart) >= l.window {
+		delete(l.attempts, key)
+		return nil
+	}
+	return record
+}
+
+// sweep drops expired records at most once per window. Must be called with l.mu held.
+func (l *AttemptLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for key, record := range l.attempts {
+		if now.Sub(record.windowStart) >= l.window {
+			delete(l.attempts, key)
+		}
+	}
+	l.lastSweep = now
+}
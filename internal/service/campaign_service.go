@@ -0,0 +1,167 @@
+package service
+
+import (
+	"github.com/tinwritescode/myapp/internal/database"
+	"github.com/tinwritescode/myapp/internal/dto/campaign"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/models"
+	"gorm.io/gorm"
+)
+
+type CampaignService interface {
+	GetTemplates(userID uint) ([]models.CampaignTemplate, error)
+	GetTemplate(id, userID uint) (*models.CampaignTemplate, error)
+	CreateTemplate(userID uint, req campaign.CreateCampaignTemplateRequest) (*models.CampaignTemplate, error)
+	UpdateTemplate(id, userID uint, req campaign.UpdateCampaignTemplateRequest) (*models.CampaignTemplate, error)
+	DeleteTemplate(id, userID uint) error
+}
+
+type campaignService struct {
+	db    *gorm.DB
+	cache URLCache
+}
+
+var (
+	campaignServiceInstance CampaignService
+)
+
+func NewCampaignService() CampaignService {
+	return &campaignService{
+		db:    database.GetDB(),
+		cache: urlCache,
+	}
+}
+
+func GetCampaignService() CampaignService {
+	if campaignServiceInstance == nil {
+		campaignServiceInstance = NewCampaignService()
+	}
+	return campaignServiceInstance
+}
+
+// findCampaignTemplate loads a campaign template owned by the user
+func findCampaignTemplate(db *gorm.DB, id, userID uint) (*models.CampaignTemplate, error) {
+	var template models.CampaignTemplate
+	if err := db.Where("id = ? AND user_id = ?", id, userID).First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewAppError(common.NOT_FOUND, "Campaign template not found", err)
+		}
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get campaign template", err)
+	}
+	return &template, nil
+}
+
+func (s *campaignService) GetTemplates(userID uint) ([]models.CampaignTemplate, error) {
+	var templates []models.CampaignTemplate
+	if err := s.db.Where("user_id = ?", userID).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get campaign templates", err)
+	}
+	return templates, nil
+}
+
+func (s *campaignService) GetTemplate(id, userID uint) (*models.CampaignTemplate, error) {
+	return findCampaignTemplate(s.db, id, userID)
+}
+
+func (s *campaignService) CreateTemplate(userID uint, req campaign.CreateCampaignTemplateRequest) (*models.CampaignTemplate, error) {
+	if err := s.checkTemplateName(userID, req.Name, 0); err != nil {
+		return nil, err
+	}
+
+	template := models.CampaignTemplate{
+		UserID: userID,
+		Name:   req.Name,
+		UTM:    models.NewUTMParams(req.UTM),
+	}
+
+	if err := s.db.Create(&template).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to create campaign template", err)
+	}
+
+	return &template, nil
+}
+
+func (s *campaignService) UpdateTemplate(id, userID uint, req campaign.UpdateCampaignTemplateRequest) (*models.CampaignTemplate, error) {
+	template, err := findCampaignTemplate(s.db, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if err := s.checkTemplateName(userID, *req.Name, template.ID); err != nil {
+			return nil, err
+		}
+		template.Name = *req.Name
+	}
+
+	if req.UTM != nil {
+		template.UTM = models.NewUTMParams(*req.UTM)
+	}
+
+	if err := s.db.Save(template).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to update campaign template", err)
+	}
+
+	s.invalidateLinks(template.ID)
+
+	return template, nil
+}
+
+func (s *campaignService) DeleteTemplate(id, userID uint) error {
+	template, err := findCampaignTemplate(s.db, id, userID)
+	if err != nil {
+		return err
+	}
+
+	// Links keep their own UTM parameters but lose the template's, deleted links included
+	keys := s.linkKeys(template.ID)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.URL{}).Where("campaign_template_id = ?", template.ID).
+			Update("campaign_template_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(template).Error
+	})
+	if err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to delete campaign template", err)
+	}
+
+	for _, key := range keys {
+		s.cache.Delete(key)
+	}
+
+	return nil
+}
+
+// invalidateLinks drops the cached copies of the links using a template
+func (s *campaignService) invalidateLinks(templateID uint) {
+	for _, key := range s.linkKeys(templateID) {
+		s.cache.Delete(key)
+	}
+}
+
+// linkKeys returns the cache keys of the links using a template
+func (s *campaignService) linkKeys(templateID uint) []string {
+	var urls []models.URL
+	s.db.Select("id", "domain_id", "short_code").Where("campaign_template_id = ?", templateID).Find(&urls)
+
+	keys := make([]string, len(urls))
+	for i := range urls {
+		keys[i] = urlKey(&urls[i])
+	}
+	return keys
+}
+
+// checkTemplateName rejects a name already used by another of the user's templates
+func (s *campaignService) checkTemplateName(userID uint, name string, excludeID uint) error {
+	var count int64
+	if err := s.db.Model(&models.CampaignTemplate{}).
+		Where("user_id = ? AND name = ? AND id <> ?", userID, name, excludeID).
+		Count(&count).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to check campaign template name", err)
+	}
+	if count > 0 {
+		return common.NewAppError(common.CONFLICT, "A campaign template with this name already exists", nil)
+	}
+	return nil
+}
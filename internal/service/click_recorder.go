@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/logger"
+)
+
+// ClickRecorder records click events asynchronously so redirects never wait on the database
+type ClickRecorder interface {
+	// Enqueue queues a click event, returning false if it was dropped because the queue is full
+	Enqueue(event models.ClickEvent) bool
+	Stats() ClickRecorderStats
+	// Shutdown stops accepting events and flushes everything still queued
+	Shutdown(ctx context.Context) error
+}
+
+// ClickRecorderOptions configures the click queue and batch flushing
+type ClickRecorderOptions struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// ClickRecorderStats holds counters describing the click queue
+type ClickRecorderStats struct {
+	Enqueued      uint64
+	Dropped       uint64
+	Flushed       uint64
+	Failed        uint64
+	QueueLength   int
+	QueueCapacity int
+}
+
+// DefaultClickRecorderOptions are used when the recorder is created lazily
+var DefaultClickRecorderOptions = ClickRecorderOptions{
+	QueueSize:     10000,
+	BatchSize:     500,
+	FlushInterval: time.Second,
+}
+
+type clickRecorder struct {
+	clickService ClickService
+	options      ClickRecorderOptions
+
+	queue chan models.ClickEvent
+	done  chan struct{}
+
+	// mu guards closed so Enqueue never sends on a closed queue
+	mu     sync.RWMutex
+	closed bool
+
+	enqueued atomic.Uint64
+	dropped  atomic.Uint64
+	flushed  atomic.Uint64
+	failed   atomic.Uint64
+}
+
+var (
+	clickRecorderInstance ClickRecorder
+	clickRecorderOnce     sync.Once
+)
+
+// NewClickRecorder creates a recorder and starts its background worker
+func NewClickRecorder(clickService ClickService, options ClickRecorderOptions) ClickRecorder {
+	if options.QueueSize <= 0 {
+		options.QueueSize = DefaultClickRecorderOptions.QueueSize
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultClickRecorderOptions.BatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = DefaultClickRecorderOptions.FlushInterval
+	}
+
+	r := &clickRecorder{
+		clickService: clickService,
+		options:      options,
+		queue:        make(chan models.ClickEvent, options.QueueSize),
+		done:         make(chan struct{}),
+	}
+	go r.run()
+
+	return r
+}
+
+// InitClickRecorder creates the shared click recorder with the given options
+func InitClickRecorder(options ClickRecorderOptions) ClickRecorder {
+	clickRecorderOnce.Do(func() {
+		clickRecorderInstance = NewClickRecorder(GetClickService(), options)
+	})
+	return clickRecorderInstance
+}
+
+// GetClickRecorder returns the shared click recorder, creating it with defaults if needed
+func GetClickRecorder() ClickRecorder {
+	return InitClickRecorder(DefaultClickRecorderOptions)
+}
+
+func (r *clickRecorder) Enqueue(event models.ClickEvent) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		r.dropped.Add(1)
+		return false
+	}
+
+	select {
+	case r.queue <- event:
+		r.enqueued.Add(1)
+		return true
+	default:
+		r.dropped.Add(1)
+		return false
+	}
+}
+
+func (r *clickRecorder) Stats() ClickRecorderStats {
+	return ClickRecorderStats{
+		Enqueued:      r.enqueued.Load(),
+		Dropped:       r.dropped.Load(),
+		Flushed:       r.flushed.Load(),
+		Failed:        r.failed.Load(),
+		QueueLength:   len(r.queue),
+		QueueCapacity: cap(r.queue),
+	}
+}
+
+func (r *clickRecorder) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run collects queued events into batches, flushing when a batch is full,
+// when the flush interval elapses, and once more when the queue is closed
+func (r *clickRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.ClickEvent, 0, r.options.BatchSize)
+	var lastDropped uint64
+
+	flush := func() {
+		if dropped := r.dropped.Load(); dropped > lastDropped {
+			logger.Warnf("Click queue full, dropped %d click events", dropped-lastDropped)
+			lastDropped = dropped
+		}
+
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := r.clickService.RecordClicks(batch); err != nil {
+			r.failed.Add(uint64(len(batch)))
+			logger.Errorf("Failed to flush %d click events: %v", len(batch), err)
+		} else {
+			r.flushed.Add(uint64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event, ok := <-r.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= r.options.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
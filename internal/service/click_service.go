@@ -0,0 +1,364 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/database"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/geoip"
+	"github.com/tinwritescode/myapp/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type ClickService interface {
+	RecordClicks(events []models.ClickEvent) error
+	GetRecentClicks(urlID uint, limit int, includeBots bool) ([]models.ClickEvent, error)
+	GetClickTimeSeries(urlID uint, from, to time.Time, interval string, loc *time.Location, includeBots bool) ([]ClickBucket, error)
+	GetClickBreakdowns(urlID uint, top int, includeBots bool) (*ClickBreakdowns, error)
+	GetVariantClicks(urlID uint, includeBots bool) (map[uint]int64, error)
+}
+
+// ClickBreakdowns holds the top values of each click dimension
+type ClickBreakdowns struct {
+	Browsers         []BreakdownCount
+	OperatingSystems []BreakdownCount
+	Devices          []BreakdownCount
+	Referrers        []BreakdownCount
+	Countries        []BreakdownCount
+	Cities           []BreakdownCount
+	Sources          []BreakdownCount
+}
+
+// BreakdownCount holds the number of clicks for a single dimension value
+type BreakdownCount struct {
+	Value  string
+	Clicks int64
+}
+
+const (
+	// DirectReferrer labels clicks that arrived without a Referer header
+	DirectReferrer = "direct"
+	// UnknownLocation labels clicks whose IP could not be attributed to a location
+	UnknownLocation = "Unknown"
+	// LinkSource labels clicks on the short link itself rather than through a QR code
+	LinkSource = "link"
+)
+
+// GeoIP lookup used to enrich click events - will be set from config
+var geoLookup = geoip.NewNoopLookup()
+
+// SetGeoIPLookup sets the GeoIP lookup used to enrich click events
+func SetGeoIPLookup(lookup geoip.Lookup) {
+	geoLookup = lookup
+}
+
+// Salt for unique visitor hashes - will be set from config
+var visitorSalt string
+
+// SetVisitorSalt sets the salt used when hashing unique visitors
+func SetVisitorSalt(salt string) {
+	visitorSalt = salt
+}
+
+// ClickBucket holds the number of clicks within a single time series bucket
+type ClickBucket struct {
+	Start  time.Time
+	Clicks int64
+}
+
+type clickService struct {
+	db *gorm.DB
+}
+
+var (
+	clickServiceInstance ClickService
+)
+
+func NewClickService() ClickService {
+	return &clickService{
+		db: database.GetDB(),
+	}
+}
+
+func GetClickService() ClickService {
+	if clickServiceInstance == nil {
+		clickServiceInstance = NewClickService()
+	}
+	return clickServiceInstance
+}
+
+// RecordClicks stores a batch of click events in a single transaction. Events are
+// attributed to a location when possible, and each URL's click, bot click and unique
+// visitor counters are incremented once per batch rather than once per event.
+func (s *clickService) RecordClicks(events []models.ClickEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	counters := make(map[uint]*clickCounters)
+	var visitors []models.URLVisitor
+	seenVisitors := make(map[string]bool)
+
+	for i := range events {
+		event := &events[i]
+		enrichClickEvent(event)
+
+		counter, ok := counters[event.URLID]
+		if !ok {
+			counter = &clickCounters{}
+			counters[event.URLID] = counter
+		}
+
+		if event.IsBot {
+			counter.botClicks++
+			continue
+		}
+		if !event.Counted {
+			counter.clicks++
+		}
+
+		visitorKey := fmt.Sprintf("%d:%s", event.URLID, event.VisitorHash)
+		if !seenVisitors[visitorKey] {
+			seenVisitors[visitorKey] = true
+			visitors = append(visitors, models.URLVisitor{
+				URLID:       event.URLID,
+				VisitorHash: event.VisitorHash,
+			})
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(events, clickInsertBatchSize).Error; err != nil {
+			return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to record clicks", err)
+		}
+
+		newVisitors, err := insertVisitors(tx, visitors)
+		if err != nil {
+			return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to record visitors", err)
+		}
+		for urlID, count := range newVisitors {
+			counters[urlID].uniqueClicks += count
+		}
+
+		for urlID, counter := range counters {
+			if err := tx.Model(&models.URL{}).Where("id = ?", urlID).Updates(map[string]interface{}{
+				"click_count":     gorm.Expr("click_count + ?", counter.clicks),
+				"bot_click_count": gorm.Expr("bot_click_count + ?", counter.botClicks),
+				"unique_clicks":   gorm.Expr("unique_clicks + ?", counter.uniqueClicks),
+			}).Error; err != nil {
+				return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to increment click counts", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// clickCounters accumulates counter increments for a single URL
+type clickCounters struct {
+	clicks       int64
+	botClicks    int64
+	uniqueClicks int64
+}
+
+// clickInsertBatchSize keeps multi-row inserts well under the Postgres parameter limit
+const clickInsertBatchSize = 500
+
+// enrichClickEvent fills in the location and visitor hash of an event
+func enrichClickEvent(event *models.ClickEvent) {
+	if event.Country == "" {
+		location := geoLookup.Lookup(event.IPAddress)
+		event.Country = location.Country
+		event.City = location.City
+	}
+
+	if event.VisitorHash == "" {
+		event.VisitorHash = utils.VisitorHash(visitorSalt, event.IPAddress, event.UserAgent, event.ClickedAt)
+	}
+}
+
+// insertVisitors stores visitors that have not been seen before and returns
+// how many new visitors were inserted for each URL
+func insertVisitors(tx *gorm.DB, visitors []models.URLVisitor) (map[uint]int64, error) {
+	newVisitors := make(map[uint]int64)
+	now := time.Now()
+
+	for start := 0; start < len(visitors); start += clickInsertBatchSize {
+		end := min(start+clickInsertBatchSize, len(visitors))
+
+		placeholders := make([]string, 0, end-start)
+		args := make([]interface{}, 0, 3*(end-start))
+		for _, visitor := range visitors[start:end] {
+			placeholders = append(placeholders, "(?, ?, ?)")
+			args = append(args, visitor.URLID, visitor.VisitorHash, now)
+		}
+
+		// ON CONFLICT DO NOTHING only returns the rows that were actually inserted
+		var urlIDs []uint
+		if err := tx.Raw("INSERT INTO url_visitors (url_id, visitor_hash, created_at) VALUES "+
+			strings.Join(placeholders, ", ")+" ON CONFLICT DO NOTHING RETURNING url_id", args...).
+			Scan(&urlIDs).Error; err != nil {
+			return nil, err
+		}
+
+		for _, urlID := range urlIDs {
+			newVisitors[urlID]++
+		}
+	}
+
+	return newVisitors, nil
+}
+
+// GetRecentClicks returns the most recent click events for a URL, newest first
+func (s *clickService) GetRecentClicks(urlID uint, limit int, includeBots bool) ([]models.ClickEvent, error) {
+	var events []models.ClickEvent
+	if err := s.clicksForURL(urlID, includeBots).
+		Order("clicked_at DESC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get recent clicks", err)
+	}
+	return events, nil
+}
+
+// GetClickTimeSeries aggregates clicks in [from, to) into interval buckets aligned to loc.
+// Buckets without clicks are included with a zero count.
+func (s *clickService) GetClickTimeSeries(urlID uint, from, to time.Time, interval string, loc *time.Location, includeBots bool) ([]ClickBucket, error) {
+	buckets, err := utils.TimeSeriesBuckets(from, to, interval, loc)
+	if err != nil {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, err.Error(), err)
+	}
+
+	// Buckets are grouped by the instant they start at. Days and weeks start at local midnight,
+	// converted back from wall-clock time. Wall-clock hours repeat when clocks go back, so an
+	// hour's start is found by stepping back from each click by its local minutes and seconds.
+	zone := loc.String()
+	bucketExpr := "date_trunc(?, clicked_at AT TIME ZONE ?) AT TIME ZONE ?"
+	bucketArgs := []interface{}{interval, zone, zone}
+	if interval == utils.IntervalHour {
+		bucketExpr = "clicked_at - ((clicked_at AT TIME ZONE ?) - date_trunc('hour', clicked_at AT TIME ZONE ?))"
+		bucketArgs = []interface{}{zone, zone}
+	}
+
+	var rows []struct {
+		Bucket time.Time
+		Clicks int64
+	}
+	if err := s.clicksForURL(urlID, includeBots).
+		Select(bucketExpr+" AS bucket, COUNT(*) AS clicks", bucketArgs...).
+		Where("clicked_at >= ? AND clicked_at < ?", from, to).
+		Group("bucket").
+		Scan(&rows).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get click time series", err)
+	}
+
+	counts := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Bucket.Unix()] = row.Clicks
+	}
+
+	series := make([]ClickBucket, len(buckets))
+	for i, start := range buckets {
+		series[i] = ClickBucket{
+			Start:  start,
+			Clicks: counts[start.Unix()],
+		}
+	}
+
+	return series, nil
+}
+
+// GetClickBreakdowns returns the top browsers, operating systems, devices, referring domains,
+// locations and sources for a URL
+func (s *clickService) GetClickBreakdowns(urlID uint, top int, includeBots bool) (*ClickBreakdowns, error) {
+	var breakdowns ClickBreakdowns
+	var err error
+
+	if breakdowns.Browsers, err = s.topValues(urlID, "browser", top, includeBots); err != nil {
+		return nil, err
+	}
+	if breakdowns.OperatingSystems, err = s.topValues(urlID, "os", top, includeBots); err != nil {
+		return nil, err
+	}
+	if breakdowns.Devices, err = s.topValues(urlID, "device_type", top, includeBots); err != nil {
+		return nil, err
+	}
+	if breakdowns.Referrers, err = s.topValues(urlID, "referer_domain", top, includeBots); err != nil {
+		return nil, err
+	}
+	if breakdowns.Countries, err = s.topValues(urlID, "country", top, includeBots); err != nil {
+		return nil, err
+	}
+	// Qualify cities with their country so identically named cities stay apart
+	if breakdowns.Cities, err = s.topValues(urlID, "CASE WHEN city = '' THEN '' ELSE city || ', ' || country END", top, includeBots); err != nil {
+		return nil, err
+	}
+
+	if breakdowns.Sources, err = s.topValues(urlID, "source", top, includeBots); err != nil {
+		return nil, err
+	}
+
+	labelEmpty(breakdowns.Referrers, DirectReferrer)
+	labelEmpty(breakdowns.Countries, UnknownLocation)
+	labelEmpty(breakdowns.Cities, UnknownLocation)
+	labelEmpty(breakdowns.Sources, LinkSource)
+
+	return &breakdowns, nil
+}
+
+// GetVariantClicks counts a URL's clicks per variant served, keyed by variant ID
+func (s *clickService) GetVariantClicks(urlID uint, includeBots bool) (map[uint]int64, error) {
+	var counts []struct {
+		VariantID uint
+		Clicks    int64
+	}
+	if err := s.clicksForURL(urlID, includeBots).
+		Select("variant_id, COUNT(*) AS clicks").
+		Where("variant_id IS NOT NULL").
+		Group("variant_id").
+		Scan(&counts).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get variant clicks", err)
+	}
+
+	clicks := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		clicks[count.VariantID] = count.Clicks
+	}
+	return clicks, nil
+}
+
+// clicksForURL scopes a query to the click events of a URL, excluding bots unless requested
+func (s *clickService) clicksForURL(urlID uint, includeBots bool) *gorm.DB {
+	query := s.db.Model(&models.ClickEvent{}).Where("url_id = ?", urlID)
+	if !includeBots {
+		query = query.Where("is_bot = ?", false)
+	}
+	return query
+}
+
+// topValues counts clicks grouped by a column expression, most frequent first.
+// expr must be trusted SQL as it is interpolated into the query.
+func (s *clickService) topValues(urlID uint, expr string, top int, includeBots bool) ([]BreakdownCount, error) {
+	var counts []BreakdownCount
+	if err := s.clicksForURL(urlID, includeBots).
+		Select(expr + " AS value, COUNT(*) AS clicks").
+		Group("value").
+		Order("clicks DESC").
+		Limit(top).
+		Scan(&counts).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get click breakdowns", err)
+	}
+	return counts, nil
+}
+
+// labelEmpty replaces empty breakdown values with a readable label
+func labelEmpty(counts []BreakdownCount, label string) {
+	for i := range counts {
+		if counts[i].Value == "" {
+			counts[i].Value = label
+		}
+	}
+}
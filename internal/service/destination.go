@@ -0,0 +1,116 @@
+package service
+
+import (
+	"math/rand/v2"
+
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/geoip"
+	"github.com/tinwritescode/myapp/pkg/logger"
+	"github.com/tinwritescode/myapp/pkg/utils"
+)
+
+// Visitor describes the client a redirect is evaluated for
+type Visitor struct {
+	DeviceType string
+	OS         string
+	IP         string
+	// StickyVariantID is the variant previously served to the visitor, or 0
+	StickyVariantID uint
+
+	location *geoip.Location // resolved on first use
+}
+
+// Location returns the visitor's location, looking up their IP at most once
+func (v *Visitor) Location() geoip.Location {
+	if v.location == nil {
+		location := geoLookup.Lookup(v.IP)
+		v.location = &location
+	}
+	return *v.location
+}
+
+// ResolvedLocation returns the visitor's location if it has already been looked up
+func (v *Visitor) ResolvedLocation() (geoip.Location, bool) {
+	if v.location == nil {
+		return geoip.Location{}, false
+	}
+	return *v.location, true
+}
+
+// DestinationFor picks where a visit to a URL goes: the target of the first matching redirect
+// rule, else one of the URL's variants, else its original destination, with the URL's UTM
+// parameters added. The variant served is returned so it can be recorded and kept for the
+// visitor's next visit. Rules must be in position order; the visitor's location is only
+// looked up if a country rule is reached.
+func DestinationFor(url *models.URL, visitor *Visitor) (string, *models.URLVariant) {
+	for i := range url.RedirectRules {
+		if ruleMatches(&url.RedirectRules[i], visitor) {
+			return withUTM(url, url.RedirectRules[i].TargetURL), nil
+		}
+	}
+
+	if variant := pickVariant(url.Variants, visitor.StickyVariantID); variant != nil {
+		return withUTM(url, variant.TargetURL), variant
+	}
+
+	return withUTM(url, url.OriginalURL), nil
+}
+
+// withUTM adds the URL's UTM parameters to a destination, leaving any it already sets
+func withUTM(url *models.URL, destination string) string {
+	params := url.EffectiveUTM().Values()
+	if len(params) == 0 {
+		return destination
+	}
+
+	merged, err := utils.MergeQueryParams(destination, params)
+	if err != nil {
+		logger.Warnf("Failed to add UTM parameters to %s: %v", destination, err)
+		return destination
+	}
+	return merged
+}
+
+func ruleMatches(rule *models.RedirectRule, visitor *Visitor) bool {
+	switch rule.Type {
+	case models.RuleTypeDevice:
+		return rule.Value == visitor.DeviceType
+	case models.RuleTypeOS:
+		return rule.Value == visitor.OS
+	case models.RuleTypeCountry:
+		return rule.Value == visitor.Location().Country
+	default:
+		return false
+	}
+}
+
+// pickVariant returns the sticky variant if it is still being served, otherwise a variant
+// chosen at random in proportion to the weights. It returns nil if no variant has weight.
+func pickVariant(variants []models.URLVariant, stickyID uint) *models.URLVariant {
+	totalWeight := 0
+	for i := range variants {
+		if variants[i].Weight <= 0 {
+			continue
+		}
+		if variants[i].ID == stickyID {
+			return &variants[i]
+		}
+		totalWeight += variants[i].Weight
+	}
+
+	if totalWeight == 0 {
+		return nil
+	}
+
+	pick := rand.IntN(totalWeight)
+	for i := range variants {
+		if variants[i].Weight <= 0 {
+			continue
+		}
+		if pick < variants[i].Weight {
+			return &variants[i]
+		}
+		pick -= variants[i].Weight
+	}
+	return nil
+}
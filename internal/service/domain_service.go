@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/database"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	domainDTO "github.com/tinwritescode/myapp/internal/dto/domain"
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type DomainService interface {
+	GetDomains(userID uint) ([]models.Domain, error)
+	CreateDomain(userID uint, req domainDTO.CreateDomainRequest) (*models.Domain, error)
+	VerifyDomain(id, userID uint) (*models.Domain, error)
+	DeleteDomain(id, userID uint) error
+	ResolveHost(host string) (uint, error)
+}
+
+// TXTResolver looks up DNS TXT records. *net.Resolver implements it.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Resolver used to verify domains - can be replaced in tests
+var txtResolver TXTResolver = net.DefaultResolver
+
+// SetTXTResolver sets the resolver used to look up domain verification records
+func SetTXTResolver(resolver TXTResolver) {
+	txtResolver = resolver
+}
+
+// domainLookupTimeout bounds how long verifying a domain waits on DNS
+const domainLookupTimeout = 5 * time.Second
+
+type domainService struct {
+	db    *gorm.DB
+	hosts *domainHostIndex
+}
+
+var (
+	domainServiceInstance DomainService
+)
+
+func NewDomainService() DomainService {
+	return &domainService{
+		db:    database.GetDB(),
+		hosts: newDomainHostIndex(time.Minute),
+	}
+}
+
+func GetDomainService() DomainService {
+	if domainServiceInstance == nil {
+		domainServiceInstance = NewDomainService()
+	}
+	return domainServiceInstance
+}
+
+// findDomain loads a domain owned by the user
+func findDomain(db *gorm.DB, id, userID uint) (*models.Domain, error) {
+	var domain models.Domain
+	if err := db.Where("id = ? AND user_id = ?", id, userID).First(&domain).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, common.NewAppError(common.NOT_FOUND, "Domain not found", err)
+		}
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get domain", err)
+	}
+	return &domain, nil
+}
+
+// generateVerificationToken returns a random token for a domain's TXT record
+func generateVerificationToken() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func (s *domainService) GetDomains(userID uint) ([]models.Domain, error) {
+	var domains []models.Domain
+	if err := s.db.Where("user_id = ?", userID).Order("hostname ASC").Find(&domains).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get domains", err)
+	}
+	return domains, nil
+}
+
+func (s *domainService) CreateDomain(userID uint, req domainDTO.CreateDomainRequest) (*models.Domain, error) {
+	hostname := utils.NormalizeHostname(req.Hostname)
+
+	// The server's own host already serves every link without a custom domain
+	if baseURL, err := url.Parse(models.ShortURLBase()); err == nil && utils.NormalizeHostname(baseURL.Host) == hostname {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, "This hostname is the server's own domain", nil)
+	}
+
+	var count int64
+	if err := s.db.Model(&models.Domain{}).Where("user_id = ? AND hostname = ?", userID, hostname).Count(&count).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to check domain", err)
+	}
+	if count > 0 {
+		return nil, common.NewAppError(common.CONFLICT, "Domain already added", nil)
+	}
+
+	token, err := generateVerificationToken()
+	if err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to generate verification token", err)
+	}
+
+	domain := models.Domain{
+		UserID:            userID,
+		Hostname:          hostname,
+		VerificationToken: token,
+	}
+
+	if err := s.db.Create(&domain).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to add domain", err)
+	}
+
+	return &domain, nil
+}
+
+// VerifyDomain checks the domain's TXT record for its verification token. Several users may
+// add the same hostname, but only the first to publish their token can verify it.
+func (s *domainService) VerifyDomain(id, userID uint) (*models.Domain, error) {
+	domain, err := findDomain(s.db, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.IsVerified() {
+		return domain, nil
+	}
+
+	var count int64
+	if err := s.db.Model(&models.Domain{}).
+		Where("hostname = ? AND verified_at IS NOT NULL AND id <> ?", domain.Hostname, domain.ID).
+		Count(&count).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to check domain", err)
+	}
+	if count > 0 {
+		return nil, common.NewAppError(common.CONFLICT, "Domain is already verified by another user", nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), domainLookupTimeout)
+	defer cancel()
+
+	records, err := txtResolver.LookupTXT(ctx, domain.VerificationRecordName())
+	if err != nil || !containsToken(records, domain.VerificationToken) {
+		return nil, common.NewAppError(common.VALIDATION_ERROR,
+			fmt.Sprintf("Verification record not found: add a TXT record %s with value %s", domain.VerificationRecordName(), domain.VerificationToken), err)
+	}
+
+	now := time.Now()
+	domain.VerifiedAt = &now
+	if err := s.db.Save(domain).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to verify domain", err)
+	}
+
+	s.hosts.Invalidate()
+
+	return domain, nil
+}
+
+func containsToken(records []string, token string) bool {
+	for _, record := range records {
+		if strings.TrimSpace(record) == token {
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteDomain removes a domain once no links are served from it
+func (s *domainService) DeleteDomain(id, userID uint) error {
+	domain, err := findDomain(s.db, id, userID)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	if err := s.db.Model(&models.URL{}).Where("domain_id = ?", domain.ID).Count(&count).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to check domain links", err)
+	}
+	if count > 0 {
+		return common.NewAppError(common.CONFLICT, fmt.Sprintf("Domain still has %d links", count), nil)
+	}
+
+	if err := s.db.Delete(domain).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to delete domain", err)
+	}
+
+	s.hosts.Invalidate()
+
+	return nil
+}
+
+// ResolveHost returns the verified custom domain a request's Host header names, or 0 for
+// any other host, including the server's own
+func (s *domainService) ResolveHost(host string) (uint, error) {
+	return s.hosts.Lookup(s.db, utils.NormalizeHostname(host))
+}
+
+// domainHostIndex keeps every verified hostname in memory so redirects on the server's own
+// domain never query for custom domains. It is reloaded after ttl so domains verified or
+// deleted by other instances are picked up.
+type domainHostIndex struct {
+	mu       sync.RWMutex
+	ttl      time.Duration
+	hosts    map[string]uint
+	loadedAt time.Time
+}
+
+func newDomainHostIndex(ttl time.Duration) *domainHostIndex {
+	return &domainHostIndex{ttl: ttl}
+}
+
+// Lookup returns the ID of the verified domain with the hostname, or 0
+func (i *domainHostIndex) Lookup(db *gorm.DB, hostname string) (uint, error) {
+	i.mu.RLock()
+	if i.hosts != nil && time.Since(i.loadedAt) < i.ttl {
+		id := i.hosts[hostname]
+		i.mu.RUnlock()
+		return id, nil
+	}
+	i.mu.RUnlock()
+
+	if err := i.reload(db); err != nil {
+		return 0, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to resolve domain", err)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.hosts[hostname], nil
+}
+
+// Invalidate makes the next lookup reload the verified domains
+func (i *domainHostIndex) Invalidate() {
+	i.mu.Lock()
+	i.hosts = nil
+	i.mu.Unlock()
+}
+
+func (i *domainHostIndex) reload(db *gorm.DB) error {
+	var domains []models.Domain
+	if err := db.Select("id", "hostname").Where("verified_at IS NOT NULL").Find(&domains).Error; err != nil {
+		return err
+	}
+
+	hosts := make(map[string]uint, len(domains))
+	for _, domain := range domains {
+		hosts[domain.Hostname] = domain.ID
+	}
+
+	i.mu.Lock()
+	i.hosts = hosts
+	i.loadedAt = time.Now()
+	i.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/utils"
+)
+
+// QRScanParam is added to the short URL encoded in QR codes so scans can be told apart from
+// other clicks. It is never forwarded to the destination.
+const QRScanParam = "qr"
+
+// QRCodeContent returns what a URL's QR code encodes: its full short URL, marked as a scan
+func QRCodeContent(url *models.URL) (string, error) {
+	shortURL := url.ShortURL()
+	if shortURL == "" {
+		return "", common.NewAppError(common.INTERNAL_SERVER_ERROR, "Short URL base is not configured", nil)
+	}
+
+	content, err := utils.MergeQueryParams(shortURL, map[string][]string{QRScanParam: {"1"}})
+	if err != nil {
+		return "", common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to build QR code content", err)
+	}
+	return content, nil
+}
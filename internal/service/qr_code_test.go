@@ -0,0 +1,39 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/models"
+)
+
+// setShortURLBase swaps the short URL base for the duration of a test
+func setShortURLBase(t *testing.T, baseURL string) {
+	t.Helper()
+	previous := models.ShortURLBase()
+	models.SetShortURLBase(baseURL)
+	t.Cleanup(func() { models.SetShortURLBase(previous) })
+}
+
+func TestQRCodeContentWithoutShortURLBase(t *testing.T) {
+	setShortURLBase(t, "")
+
+	content, err := QRCodeContent(&models.URL{ShortCode: "abc123"})
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) || appErr.Code != common.INTERNAL_SERVER_ERROR {
+		t.Fatalf("QRCodeContent = %q, %v, want an INTERNAL_SERVER_ERROR app error", content, err)
+	}
+}
+
+func TestQRCodeContentMarksScans(t *testing.T) {
+	setShortURLBase(t, "https://go.example.com/")
+
+	content, err := QRCodeContent(&models.URL{ShortCode: "abc123"})
+	if err != nil {
+		t.Fatalf("QRCodeContent: %v", err)
+	}
+	if want := "https://go.example.com/abc123?qr=1"; content != want {
+		t.Errorf("QRCodeContent = %q, want %q", content, want)
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tinwritescode/myapp/internal/database"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	urlDTO "github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/geoip"
+	"github.com/tinwritescode/myapp/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// MaxRedirectRulesPerURL caps how many rules are evaluated on every redirect of a URL
+const MaxRedirectRulesPerURL = 20
+
+type RedirectRuleService interface {
+	GetRules(urlID uint, userID *uint) ([]models.RedirectRule, error)
+	CreateRule(urlID uint, userID *uint, req urlDTO.CreateRedirectRuleRequest) (*models.RedirectRule, error)
+	UpdateRule(urlID, ruleID uint, userID *uint, req urlDTO.UpdateRedirectRuleRequest) (*models.RedirectRule, error)
+	DeleteRule(urlID, ruleID uint, userID *uint) error
+}
+
+type redirectRuleService struct {
+	db         *gorm.DB
+	cache      URLCache
+	urlService URLService
+}
+
+var (
+	redirectRuleServiceInstance RedirectRuleService
+)
+
+func NewRedirectRuleService() RedirectRuleService {
+	return &redirectRuleService{
+		db:         database.GetDB(),
+		cache:      urlCache,
+		urlService: GetURLService(),
+	}
+}
+
+func GetRedirectRuleService() RedirectRuleService {
+	if redirectRuleServiceInstance == nil {
+		redirectRuleServiceInstance = NewRedirectRuleService()
+	}
+	return redirectRuleServiceInstance
+}
+
+// orderedRedirectRules preloads a URL's rules in evaluation order
+func orderedRedirectRules(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
+// normalizeRuleValue validates a rule value for its type and returns its canonical form
+func normalizeRuleValue(ruleType, value string) (string, error) {
+	switch ruleType {
+	case models.RuleTypeDevice:
+		if canonical, ok := utils.CanonicalDeviceType(value); ok {
+			return canonical, nil
+		}
+		return "", common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid device: must be one of %v", utils.KnownDeviceTypes), nil)
+	case models.RuleTypeOS:
+		if canonical, ok := utils.CanonicalOS(value); ok {
+			return canonical, nil
+		}
+		return "", common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid operating system: must be one of %v", utils.KnownOperatingSystems), nil)
+	case models.RuleTypeCountry:
+		if code, ok := geoip.NormalizeCountryCode(value); ok {
+			return code, nil
+		}
+		return "", common.NewAppError(common.VALIDATION_ERROR, "Invalid country: must be an ISO 3166-1 alpha-2 code such as US", nil)
+	default:
+		return "", common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid rule type: %s", ruleType), nil)
+	}
+}
+
+// normalizeTargetURL validates a rule's destination like a URL's original one
+func normalizeTargetURL(targetURL string) (string, error) {
+	if err := utils.ValidateURL(targetURL); err != nil {
+		return "", common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid target URL: %s", err.Error()), err)
+	}
+	return utils.NormalizeURL(targetURL), nil
+}
+
+func (s *redirectRuleService) GetRules(urlID uint, userID *uint) ([]models.RedirectRule, error) {
+	// Ensure the URL exists and belongs to the user
+	if _, err := s.urlService.GetURLByID(urlID, userID); err != nil {
+		return nil, err
+	}
+
+	var rules []models.RedirectRule
+	if err := orderedRedirectRules(s.db).Where("url_id = ?", urlID).Find(&rules).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get redirect rules", err)
+	}
+
+	return rules, nil
+}
+
+func (s *redirectRuleService) CreateRule(urlID uint, userID *uint, req urlDTO.CreateRedirectRuleRequest) (*models.RedirectRule, error) {
+	url, err := s.urlService.GetURLByID(urlID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(url.RedirectRules) >= MaxRedirectRulesPerURL {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("A URL can have at most %d redirect rules", MaxRedirectRulesPerURL), nil)
+	}
+
+	value, err := normalizeRuleValue(req.Type, req.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	targetURL, err := normalizeTargetURL(req.TargetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// New rules go after the existing ones unless a position is given
+	position := 0
+	if req.Position != nil {
+		position = *req.Position
+	} else if count := len(url.RedirectRules); count > 0 {
+		position = url.RedirectRules[count-1].Position + 1
+	}
+
+	rule := models.RedirectRule{
+		URLID:     url.ID,
+		Position:  position,
+		Type:      req.Type,
+		Value:     value,
+		TargetURL: targetURL,
+	}
+
+	if err := s.db.Create(&rule).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to create redirect rule", err)
+	}
+
+	s.cache.Delete(urlKey(url))
+
+	return &rule, nil
+}
+
+func (s *redirectRuleService) UpdateRule(urlID, ruleID uint, userID *uint, req urlDTO.UpdateRedirectRuleRequest) (*models.RedirectRule, error) {
+	url, rule, err := s.getRule(urlID, ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Type != nil {
+		rule.Type = *req.Type
+	}
+
+	// The value is checked against the rule's type even if only the type changed
+	value := rule.Value
+	if req.Value != nil {
+		value = *req.Value
+	}
+	if rule.Value, err = normalizeRuleValue(rule.Type, value); err != nil {
+		return nil, err
+	}
+
+	if req.TargetURL != nil {
+		if rule.TargetURL, err = normalizeTargetURL(*req.TargetURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Position != nil {
+		rule.Position = *req.Position
+	}
+
+	if err := s.db.Save(rule).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to update redirect rule", err)
+	}
+
+	s.cache.Delete(urlKey(url))
+
+	return rule, nil
+}
+
+func (s *redirectRuleService) DeleteRule(urlID, ruleID uint, userID *uint) error {
+	url, rule, err := s.getRule(urlID, ruleID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(rule).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to delete redirect rule", err)
+	}
+
+	s.cache.Delete(urlKey(url))
+
+	return nil
+}
+
+// getRule loads a rule after checking its URL exists and belongs to the user
+func (s *redirectRuleService) getRule(urlID, ruleID uint, userID *uint) (*models.URL, *models.RedirectRule, error) {
+	url, err := s.urlService.GetURLByID(urlID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rule models.RedirectRule
+	if err := s.db.Where("id = ? AND url_id = ?", ruleID, urlID).First(&rule).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, common.NewAppError(common.NOT_FOUND, "Redirect rule not found", err)
+		}
+		return nil, nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get redirect rule", err)
+	}
+
+	return url, &rule, nil
+}
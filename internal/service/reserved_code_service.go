@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tinwritescode/myapp/internal/database"
+	adminDTO "github.com/tinwritescode/myapp/internal/dto/admin"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/internal/models"
+	"gorm.io/gorm"
+)
+
+type ReservedCodeService interface {
+	GetReservedCodes() ([]models.ReservedCode, error)
+	CreateReservedCode(adminID uint, req adminDTO.CreateReservedCodeRequest) (*models.ReservedCode, error)
+	DeleteReservedCode(id uint) error
+}
+
+type reservedCodeService struct {
+	db *gorm.DB
+}
+
+var (
+	reservedCodeServiceInstance ReservedCodeService
+)
+
+func NewReservedCodeService() ReservedCodeService {
+	return &reservedCodeService{
+		db: database.GetDB(),
+	}
+}
+
+func GetReservedCodeService() ReservedCodeService {
+	if reservedCodeServiceInstance == nil {
+		reservedCodeServiceInstance = NewReservedCodeService()
+	}
+	return reservedCodeServiceInstance
+}
+
+// findReservation returns the reserved code or blocked word that takes a short code out of
+// use, or nil if it is free
+func findReservation(db *gorm.DB, shortCode string) (*models.ReservedCode, error) {
+	lowerCode := strings.ToLower(shortCode)
+
+	var reservations []models.ReservedCode
+	if err := db.Where("(type = ? AND code = ?) OR (type = ? AND strpos(?, code) > 0)",
+		models.ReservedCodeTypeReserved, lowerCode, models.ReservedCodeTypeBlocked, lowerCode).
+		Limit(1).Find(&reservations).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to check reserved codes", err)
+	}
+
+	if len(reservations) == 0 {
+		return nil, nil
+	}
+	return &reservations[0], nil
+}
+
+// reservationError describes why a reservation rejects a short code, worded like the
+// short code policy's errors
+func reservationError(shortCode string, reservation *models.ReservedCode) error {
+	if reservation.Type == models.ReservedCodeTypeBlocked {
+		return fmt.Errorf("short code '%s' contains a blocked word", shortCode)
+	}
+	return fmt.Errorf("short code '%s' is reserved", shortCode)
+}
+
+func (s *reservedCodeService) GetReservedCodes() ([]models.ReservedCode, error) {
+	var reservedCodes []models.ReservedCode
+	if err := s.db.Order("code ASC").Find(&reservedCodes).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get reserved codes", err)
+	}
+	return reservedCodes, nil
+}
+
+// CreateReservedCode takes a code or word out of use for new links. Existing links keep
+// working.
+func (s *reservedCodeService) CreateReservedCode(adminID uint, req adminDTO.CreateReservedCodeRequest) (*models.ReservedCode, error) {
+	reservedCode := models.ReservedCode{
+		Code:      strings.ToLower(req.Code),
+		Type:      req.Type,
+		Reason:    req.Reason,
+		CreatedBy: adminID,
+	}
+
+	if err := s.db.Create(&reservedCode).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, common.NewAppError(common.CONFLICT, "Code is already reserved", err)
+		}
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to reserve code", err)
+	}
+
+	return &reservedCode, nil
+}
+
+func (s *reservedCodeService) DeleteReservedCode(id uint) error {
+	result := s.db.Delete(&models.ReservedCode{}, id)
+	if result.Error != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to delete reserved code", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return common.NewAppError(common.NOT_FOUND, "Reserved code not found", nil)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/logger"
+	"github.com/tinwritescode/myapp/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// ShortCodeFilter is a bloom filter of every existing short code, keyed by shortCodeKey, used to reject lookups
+// for codes that were never created without querying the database. Until the first
+// rebuild completes every code is treated as possibly existing.
+//
+// The filter only sees codes created by this process between rebuilds, so other instances
+// would answer 404 for new links until they rebuild. It must only be enabled when running a
+// single instance.
+type ShortCodeFilter struct {
+	mu                sync.RWMutex
+	bloom             *utils.BloomFilter
+	falsePositiveRate float64
+	rebuilding        bool
+	pending           []string // codes added while a rebuild is in progress
+}
+
+// Short code filter - will be set from config
+var shortCodeFilter *ShortCodeFilter
+
+// SetShortCodeFilter sets the bloom filter consulted before short code lookups
+func SetShortCodeFilter(filter *ShortCodeFilter) {
+	shortCodeFilter = filter
+}
+
+// NewShortCodeFilter creates an empty filter that is populated by Rebuild
+func NewShortCodeFilter(falsePositiveRate float64) *ShortCodeFilter {
+	return &ShortCodeFilter{falsePositiveRate: falsePositiveRate}
+}
+
+// MightExist reports whether a short code may exist. It is safe to call on a nil filter.
+func (f *ShortCodeFilter) MightExist(shortCode string) bool {
+	if f == nil {
+		return true
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.bloom == nil {
+		return true
+	}
+	return f.bloom.MightContain(shortCode)
+}
+
+// Add records a newly created short code. It is safe to call on a nil filter.
+func (f *ShortCodeFilter) Add(shortCode string) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.bloom != nil {
+		f.bloom.Add(shortCode)
+	}
+	if f.rebuilding {
+		f.pending = append(f.pending, shortCode)
+	}
+}
+
+// Rebuild repopulates the filter from the database. Codes added while the rebuild
+// is running are carried over into the new filter.
+func (f *ShortCodeFilter) Rebuild(db *gorm.DB) error {
+	f.mu.Lock()
+	f.rebuilding = true
+	f.pending = nil
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.rebuilding = false
+		f.pending = nil
+		f.mu.Unlock()
+	}()
+
+	var count int64
+	if err := db.Model(&models.URL{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	// Leave headroom so codes created before the next rebuild keep the false positive rate down
+	bloom := utils.NewBloomFilter(int(count)*2+10000, f.falsePositiveRate)
+
+	var batch []models.URL
+	if err := db.Model(&models.URL{}).Select("id", "domain_id", "short_code").
+		FindInBatches(&batch, 10000, func(tx *gorm.DB, _ int) error {
+			for _, url := range batch {
+				bloom.Add(urlKey(&url))
+			}
+			return nil
+		}).Error; err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	for _, shortCode := range f.pending {
+		bloom.Add(shortCode)
+	}
+	f.bloom = bloom
+	f.mu.Unlock()
+
+	logger.Infof("Short code filter rebuilt with %d codes", count)
+	return nil
+}
+
+// StartRebuilding rebuilds the filter now and then every interval in the background
+func (f *ShortCodeFilter) StartRebuilding(db *gorm.DB, interval time.Duration) {
+	if err := f.Rebuild(db); err != nil {
+		logger.Errorf("Failed to build short code filter: %v", err)
+	}
+
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := f.Rebuild(db); err != nil {
+				logger.Errorf("Failed to rebuild short code filter: %v", err)
+			}
+		}
+	}()
+}
+
+// NegativeCache remembers short codes that recently resolved to nothing, so repeated
+// misses are answered without querying the database. All methods are safe on a nil cache.
+//
+// Creating a link only clears the miss in this process, so other instances would answer 404
+// for it until the miss expires. It must only be enabled when running a single instance.
+type NegativeCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently added
+}
+
+type negativeEntry struct {
+	shortCode string
+	expiresAt time.Time
+}
+
+// Negative cache - will be set from config
+var negativeCache *NegativeCache
+
+// SetNegativeCache sets the cache of short codes known not to exist
+func SetNegativeCache(cache *NegativeCache) {
+	negativeCache = cache
+}
+
+// NewNegativeCache returns a cache remembering at most capacity misses for ttl each
+func NewNegativeCache(capacity int, ttl time.Duration) *NegativeCache {
+	return &NegativeCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Contains reports whether a short code is known not to exist
+func (c *NegativeCache) Contains(shortCode string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[shortCode]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(element.Value.(*negativeEntry).expiresAt) {
+		c.removeElement(element)
+		return false
+	}
+	return true
+}
+
+// Add remembers that a short code does not exist
+func (c *NegativeCache) Add(shortCode string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &negativeEntry{
+		shortCode: shortCode,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+
+	if element, ok := c.entries[shortCode]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[shortCode] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Remove forgets a short code, e.g. because it has just been created or reactivated
+func (c *NegativeCache) Remove(shortCode string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[shortCode]; ok {
+		c.removeElement(element)
+	}
+}
+
+// removeElement must be called with c.mu held
+func (c *NegativeCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*negativeEntry).shortCode)
+}
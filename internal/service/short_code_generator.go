@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// ShortCodeGenerator proposes short codes for new links. CreateURL inserts each candidate
+// and relies on the unique index to detect collisions, asking for another candidate with the
+// next attempt number when one is taken. Implementations must be safe for concurrent use.
+type ShortCodeGenerator interface {
+	Generate(attempt int) (string, error)
+}
+
+// Short code strategies
+const (
+	// ShortCodeStrategyRandom generates random codes, lengthening them if collisions repeat
+	ShortCodeStrategyRandom = "random"
+	// ShortCodeStrategySequence base62-encodes values of a database sequence
+	ShortCodeStrategySequence = "sequence"
+)
+
+// maxShortCodeAttempts bounds how many generated codes CreateURL tries before giving up
+const maxShortCodeAttempts = 10
+
+// errShortCodeTaken is returned by the create function of createWithGeneratedCode when a
+// code is already in use, so another one is tried
+var errShortCodeTaken = errors.New("short code taken")
+
+// createWithGeneratedCode calls create with codes from generator until one is free, giving
+// up after maxShortCodeAttempts codes. Reserved codes, such as route names, are skipped like
+// custom codes using them would be rejected.
+func createWithGeneratedCode(generator ShortCodeGenerator, create func(code string) error) error {
+	for attempt := 0; attempt < maxShortCodeAttempts; attempt++ {
+		code, err := generator.Generate(attempt)
+		if err != nil {
+			return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to generate short code", err)
+		}
+		if utils.IsReservedShortCode(code) {
+			continue
+		}
+
+		if err := create(code); !errors.Is(err, errShortCodeTaken) {
+			return err
+		}
+	}
+
+	return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to generate unique short code", nil)
+}
+
+// Short code generator - will be set from config
+var shortCodeGenerator ShortCodeGenerator = NewRandomShortCodeGenerator(3)
+
+// SetShortCodeGenerator sets the strategy used to generate short codes
+func SetShortCodeGenerator(generator ShortCodeGenerator) {
+	shortCodeGenerator = generator
+}
+
+type randomShortCodeGenerator struct {
+	attemptsPerLength int
+}
+
+// NewRandomShortCodeGenerator returns a generator of random codes of the short code policy's
+// length. After every attemptsPerLength collisions for the same link the codes grow by one
+// character, up to the policy's max length, so creation keeps succeeding as the code space
+// fills up.
+func NewRandomShortCodeGenerator(attemptsPerLength int) ShortCodeGenerator {
+	if attemptsPerLength < 1 {
+		attemptsPerLength = 1
+	}
+	return &randomShortCodeGenerator{
+		attemptsPerLength: attemptsPerLength,
+	}
+}
+
+func (g *randomShortCodeGenerator) Generate(attempt int) (string, error) {
+	policy := utils.GetShortCodePolicy()
+	length := min(policy.Length+attempt/g.attemptsPerLength, policy.MaxLength)
+	return utils.GenerateShortCode(length)
+}
+
+type sequenceShortCodeGenerator struct {
+	// next returns the next value of the sequence
+	next func() (int64, error)
+}
+
+// shortCodeSequence is the database sequence numbering sequential short codes
+const shortCodeSequence = "short_code_seq"
+
+// NewSequenceShortCodeGenerator returns a generator encoding values of a database sequence in
+// the short code policy's alphabet, creating the sequence if needed. It starts at the first
+// number with the policy's length in digits so codes never get shorter than that. Sequential
+// codes never collide with each other, only with custom codes, but they are predictable:
+// anyone can enumerate the links.
+func NewSequenceShortCodeGenerator(db *gorm.DB) (ShortCodeGenerator, error) {
+	policy := utils.GetShortCodePolicy()
+	start := int64(math.Pow(float64(len(policy.Alphabet)), float64(policy.Length-1)))
+	if err := db.Exec(fmt.Sprintf("CREATE SEQUENCE IF NOT EXISTS %s START WITH %d", shortCodeSequence, start)).Error; err != nil {
+		return nil, fmt.Errorf("failed to create short code sequence: %w", err)
+	}
+
+	return &sequenceShortCodeGenerator{
+		next: func() (int64, error) {
+			var value int64
+			err := db.Raw("SELECT nextval(?)", shortCodeSequence).Scan(&value).Error
+			return value, err
+		},
+	}, nil
+}
+
+// maxBlockedSequenceValues bounds how many blocklisted codes Generate skips in a row
+const maxBlockedSequenceValues = 100
+
+func (g *sequenceShortCodeGenerator) Generate(int) (string, error) {
+	for range maxBlockedSequenceValues {
+		value, err := g.next()
+		if err != nil {
+			return "", fmt.Errorf("failed to get next short code: %w", err)
+		}
+
+		if code := utils.EncodeShortCode(uint64(value)); !utils.IsBlockedShortCode(code) {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("failed to get a short code without blocked words")
+}
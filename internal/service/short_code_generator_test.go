@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	"github.com/tinwritescode/myapp/pkg/utils"
+)
+
+// setShortCodePolicy swaps the short code policy for the duration of a test
+func setShortCodePolicy(t *testing.T, policy utils.ShortCodePolicy) {
+	t.Helper()
+	previous := utils.GetShortCodePolicy()
+	if err := utils.SetShortCodePolicy(policy); err != nil {
+		t.Fatalf("SetShortCodePolicy: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := utils.SetShortCodePolicy(previous); err != nil {
+			t.Fatalf("restoring short code policy: %v", err)
+		}
+	})
+}
+
+func TestRandomShortCodeGeneratorLengthensAfterCollisions(t *testing.T) {
+	policy := utils.DefaultShortCodePolicy()
+	policy.Length, policy.MaxLength = 6, 8
+	setShortCodePolicy(t, policy)
+
+	generator := NewRandomShortCodeGenerator(3)
+	wantLengths := []int{6, 6, 6, 7, 7, 7, 8, 8, 8, 8, 8, 8}
+	for attempt, want := range wantLengths {
+		code, err := generator.Generate(attempt)
+		if err != nil {
+			t.Fatalf("Generate(%d): %v", attempt, err)
+		}
+		if len(code) != want {
+			t.Errorf("Generate(%d) = %q, want %d characters", attempt, code, want)
+		}
+	}
+}
+
+func TestCreateWithGeneratedCodeUnderHighCollisionRate(t *testing.T) {
+	policy := utils.DefaultShortCodePolicy()
+	policy.Length, policy.MaxLength = 6, 8
+	setShortCodePolicy(t, policy)
+
+	// Every code shorter than the max length is taken, as if that code space were full
+	var tried []string
+	err := createWithGeneratedCode(NewRandomShortCodeGenerator(3), func(code string) error {
+		tried = append(tried, code)
+		if len(code) < policy.MaxLength {
+			return errShortCodeTaken
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("createWithGeneratedCode: %v", err)
+	}
+
+	if len(tried) != 7 {
+		t.Fatalf("tried %d codes, want 7: %v", len(tried), tried)
+	}
+	if got := tried[len(tried)-1]; len(got) != policy.MaxLength {
+		t.Errorf("created code %q, want %d characters", got, policy.MaxLength)
+	}
+}
+
+func TestCreateWithGeneratedCodeGivesUpAfterMaxAttempts(t *testing.T) {
+	policy := utils.DefaultShortCodePolicy()
+	policy.Length, policy.MaxLength = 6, 8
+	setShortCodePolicy(t, policy)
+
+	calls := 0
+	err := createWithGeneratedCode(NewRandomShortCodeGenerator(3), func(code string) error {
+		calls++
+		if len(code) > policy.MaxLength {
+			t.Errorf("code %q is longer than the max length %d", code, policy.MaxLength)
+		}
+		return errShortCodeTaken
+	})
+
+	if calls != maxShortCodeAttempts {
+		t.Errorf("create called %d times, want %d", calls, maxShortCodeAttempts)
+	}
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) || appErr.Code != common.INTERNAL_SERVER_ERROR {
+		t.Errorf("err = %v, want an INTERNAL_SERVER_ERROR app error", err)
+	}
+}
+
+func TestCreateWithGeneratedCodeStopsOnOtherErrors(t *testing.T) {
+	failure := errors.New("database is down")
+	calls := 0
+	err := createWithGeneratedCode(NewRandomShortCodeGenerator(3), func(string) error {
+		calls++
+		return failure
+	})
+
+	if !errors.Is(err, failure) {
+		t.Errorf("err = %v, want %v", err, failure)
+	}
+	if calls != 1 {
+		t.Errorf("create called %d times, want 1", calls)
+	}
+}
+
+func TestSequenceShortCodeGeneratorSkipsBlockedValues(t *testing.T) {
+	policy := utils.DefaultShortCodePolicy()
+	policy.Blocklist = []string{"zz"}
+	setShortCodePolicy(t, policy)
+
+	// 3843 encodes to "zz" in base62; the sequence continues with 3844, "100"
+	value := int64(3843)
+	generator := &sequenceShortCodeGenerator{
+		next: func() (int64, error) {
+			value++
+			return value - 1, nil
+		},
+	}
+
+	code, err := generator.Generate(0)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if code != "100" {
+		t.Errorf("Generate = %q, want %q", code, "100")
+	}
+}
+
+func TestSequenceShortCodeGeneratorGivesUpOnBlockedRun(t *testing.T) {
+	policy := utils.DefaultShortCodePolicy()
+	policy.Blocklist = []string{"zz"}
+	setShortCodePolicy(t, policy)
+
+	calls := 0
+	generator := &sequenceShortCodeGenerator{
+		next: func() (int64, error) {
+			calls++
+			return 3843, nil
+		},
+	}
+
+	if _, err := generator.Generate(0); err == nil {
+		t.Error("Generate succeeded, want an error when every value is blocked")
+	}
+	if calls != maxBlockedSequenceValues {
+		t.Errorf("next called %d times, want %d", calls, maxBlockedSequenceValues)
+	}
+}
+
+// fixedShortCodeGenerator returns its codes in order, one per attempt
+type fixedShortCodeGenerator []string
+
+func (g fixedShortCodeGenerator) Generate(attempt int) (string, error) {
+	return g[attempt], nil
+}
+
+func TestCreateWithGeneratedCodeSkipsReservedCodes(t *testing.T) {
+	policy := utils.DefaultShortCodePolicy()
+	policy.Reserved = []string{"swagger", "search"}
+	setShortCodePolicy(t, policy)
+
+	var created string
+	err := createWithGeneratedCode(fixedShortCodeGenerator{"Swagger", "search", "abc123"}, func(code string) error {
+		created = code
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("createWithGeneratedCode: %v", err)
+	}
+	if created != "abc123" {
+		t.Errorf("created %q, want the first code that isn't reserved", created)
+	}
+}
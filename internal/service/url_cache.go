@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/gob"
+	"sync"
+	"time"
+
+	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/logger"
+	"github.com/tinwritescode/myapp/pkg/utils"
+)
+
+// URLCache caches URLs resolved by short code. Implementations must be safe for concurrent use.
+type URLCache interface {
+	Get(shortCode string) (*models.URL, bool)
+	Set(shortCode string, url *models.URL)
+	Delete(shortCode string)
+}
+
+// RemoteCacheClient is the subset of a Redis-compatible client needed by the URL cache.
+// Get must return an error when the key does not exist.
+type RemoteCacheClient interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, key string) error
+}
+
+// URL cache - will be set from config
+var urlCache URLCache = NewNoopURLCache()
+
+// SetURLCache sets the cache used to resolve short codes without a database round-trip
+func SetURLCache(cache URLCache) {
+	urlCache = cache
+}
+
+// cacheTTL returns how long a URL may be cached: at most ttl, and never past its expiry
+func cacheTTL(url *models.URL, ttl time.Duration) time.Duration {
+	if url.ExpiresAt != nil {
+		if untilExpiry := time.Until(*url.ExpiresAt); untilExpiry < ttl {
+			return untilExpiry
+		}
+	}
+	return ttl
+}
+
+// isCacheable reports whether a URL can currently be served from cache. Click-limited
+// URLs are never cached as every redirect changes their state.
+func isCacheable(url *models.URL) bool {
+	return url.IsActive && url.MaxClicks == nil && utils.CheckURLWindow(url.ActivatesAt, url.ExpiresAt) == nil
+}
+
+type noopURLCache struct{}
+
+// NewNoopURLCache returns a cache that never stores anything
+func NewNoopURLCache() URLCache {
+	return noopURLCache{}
+}
+
+func (noopURLCache) Get(string) (*models.URL, bool) { return nil, false }
+func (noopURLCache) Set(string, *models.URL)        {}
+func (noopURLCache) Delete(string)                  {}
+
+type lruEntry struct {
+	shortCode string
+	url       models.URL
+	expiresAt time.Time
+}
+
+type lruURLCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List // front is most recently used
+}
+
+// NewLRUURLCache returns an in-memory cache holding at most capacity URLs for up to ttl each.
+// Updates and deletes only clear this process's cache, so other instances keep serving the
+// old URL until its entry expires. It must only be used when running a single instance.
+func NewLRUURLCache(capacity int, ttl time.Duration) URLCache {
+	return &lruURLCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *lruURLCache) Get(shortCode string) (*models.URL, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[shortCode]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) || !isCacheable(&entry.url) {
+		c.removeElement(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	url := entry.url
+	return &url, true
+}
+
+func (c *lruURLCache) Set(shortCode string, url *models.URL) {
+	if !isCacheable(url) {
+		c.Delete(shortCode)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{
+		shortCode: shortCode,
+		url:       *url,
+		expiresAt: time.Now().Add(cacheTTL(url, c.ttl)),
+	}
+
+	if element, ok := c.entries[shortCode]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[shortCode] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *lruURLCache) Delete(shortCode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[shortCode]; ok {
+		c.removeElement(element)
+	}
+}
+
+// removeElement must be called with c.mu held
+func (c *lruURLCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).shortCode)
+}
+
+type remoteURLCache struct {
+	client  RemoteCacheClient
+	prefix  string
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+// NewRemoteURLCache adapts a Redis-compatible client to URLCache, storing URLs gob-encoded
+// under prefix+shortCode. Gob is used rather than JSON so fields hidden from API
+// responses survive the round-trip. Client errors are logged and treated as cache misses.
+func NewRemoteURLCache(client RemoteCacheClient, prefix string, ttl time.Duration) URLCache {
+	return &remoteURLCache{
+		client:  client,
+		prefix:  prefix,
+		ttl:     ttl,
+		timeout: 100 * time.Millisecond,
+	}
+}
+
+func (c *remoteURLCache) Get(shortCode string) (*models.URL, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.prefix+shortCode)
+	if err != nil {
+		return nil, false
+	}
+
+	var url models.URL
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&url); err != nil || !isCacheable(&url) {
+		return nil, false
+	}
+	return &url, true
+}
+
+func (c *remoteURLCache) Set(shortCode string, url *models.URL) {
+	if !isCacheable(url) {
+		c.Delete(shortCode)
+		return
+	}
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(url); err != nil {
+		logger.Errorf("Failed to encode URL %s for cache: %v", shortCode, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.client.Set(ctx, c.prefix+shortCode, data.Bytes(), cacheTTL(url, c.ttl)); err != nil {
+		logger.Warnf("Failed to cache URL %s: %v", shortCode, err)
+	}
+}
+
+func (c *remoteURLCache) Delete(shortCode string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	if err := c.client.Del(ctx, c.prefix+shortCode); err != nil {
+		logger.Warnf("Failed to invalidate cached URL %s: %v", shortCode, err)
+	}
+}
@@ -1,30 +1,41 @@
 package service
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/tinwritescode/myapp/internal/database"
 	"github.com/tinwritescode/myapp/internal/dto/common"
+	urlDTO "github.com/tinwritescode/myapp/internal/dto/url"
 	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/logger"
 	"github.com/tinwritescode/myapp/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type URLService interface {
-	CreateURL(originalURL string, shortCode *string, userID *uint, expiresAt *time.Time) (*models.URL, error)
-	GetURLByShortCode(shortCode string) (*models.URL, error)
+	CreateURL(req urlDTO.CreateURLRequest, userID *uint) (*models.URL, error)
+	GetURLByShortCode(domainID uint, shortCode string) (*models.URL, error)
 	GetURLByID(id uint, userID *uint) (*models.URL, error)
 	GetURLs(userID *uint, page, limit int, search *string, isActive *bool, sortBy, sortDir string) ([]models.URL, int64, error)
-	UpdateURL(id uint, userID *uint, originalURL *string, expiresAt *time.Time, isActive *bool) (*models.URL, error)
+	UpdateURL(id uint, userID *uint, req urlDTO.UpdateURLRequest) (*models.URL, error)
 	DeleteURL(id uint, userID *uint) error
-	IncrementClickCount(shortCode string) error
 	GetURLStats(id uint, userID *uint) (*models.URL, error)
+	UnlockURL(url *models.URL, password, clientIP string) error
+	ClaimClick(url *models.URL) error
+	FallbackURLFor(url *models.URL) string
 }
 
 type urlService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	cache     URLCache
+	filter    *ShortCodeFilter
+	negative  *NegativeCache
+	generator ShortCodeGenerator
 }
 
 var (
@@ -33,7 +44,11 @@ var (
 
 func NewURLService() URLService {
 	return &urlService{
-		db: database.GetDB(),
+		db:        database.GetDB(),
+		cache:     urlCache,
+		filter:    shortCodeFilter,
+		negative:  negativeCache,
+		generator: shortCodeGenerator,
 	}
 }
 
@@ -44,93 +59,329 @@ func GetURLService() URLService {
 	return urlServiceInstance
 }
 
-func (s *urlService) CreateURL(originalURL string, shortCode *string, userID *uint, expiresAt *time.Time) (*models.URL, error) {
+// Default redirect status - will be set from config
+var defaultRedirectStatus = http.StatusFound
+
+// SetDefaultRedirectStatus sets the redirect status used for links that don't choose one
+func SetDefaultRedirectStatus(status int) {
+	if utils.IsValidRedirectStatus(status) {
+		defaultRedirectStatus = status
+	}
+}
+
+// RedirectStatusFor returns the HTTP status a URL should redirect with
+func RedirectStatusFor(url *models.URL) int {
+	if utils.IsValidRedirectStatus(url.RedirectStatus) {
+		return url.RedirectStatus
+	}
+	return defaultRedirectStatus
+}
+
+// withDestinations preloads the redirect rules and variants that choose a URL's destination,
+// the campaign template whose UTM parameters are added to it and the domain it is served from
+func withDestinations(db *gorm.DB) *gorm.DB {
+	return db.Preload("RedirectRules", orderedRedirectRules).Preload("Variants", orderedVariants).
+		Preload("CampaignTemplate").Preload("Domain")
+}
+
+// shortCodeKey identifies a short code on a domain in the URL cache, negative cache and
+// short code filter. Codes on the server's own domain are keyed by the code alone.
+func shortCodeKey(domainID uint, shortCode string) string {
+	shortCode = utils.NormalizeShortCode(shortCode)
+	if domainID == 0 {
+		return shortCode
+	}
+	return fmt.Sprintf("%d/%s", domainID, shortCode)
+}
+
+// whereShortCode matches a short code on a domain, ignoring case when short codes are
+// case-insensitive. Codes created before the mode was enabled may still have capitals.
+func whereShortCode(db *gorm.DB, domainID uint, shortCode string) *gorm.DB {
+	if utils.GetShortCodePolicy().CaseInsensitive {
+		return db.Where("lower(short_code) = ? AND domain_id = ?", utils.NormalizeShortCode(shortCode), domainID)
+	}
+	return db.Where("short_code = ? AND domain_id = ?", shortCode, domainID)
+}
+
+// urlKey returns the cache key of a URL
+func urlKey(url *models.URL) string {
+	return shortCodeKey(url.DomainID, url.ShortCode)
+}
+
+// hasLinkOptions reports whether a create request configures per-link behaviour,
+// in which case an existing link to the same destination must not be reused
+func hasLinkOptions(req urlDTO.CreateURLRequest) bool {
+	return req.RedirectStatus != nil || (req.Password != nil && *req.Password != "") || req.MaxClicks != nil ||
+		req.ActivatesAt != nil || req.FallbackURL != nil || req.UTM != nil || req.CampaignTemplateID != nil ||
+		req.ForwardQuery != nil || req.ForwardPath != nil
+}
+
+// domainFor loads the verified custom domain a user's link should be served from, nil for
+// the server's own domain
+func (s *urlService) domainFor(id *uint, userID *uint) (*models.Domain, error) {
+	if id == nil || *id == 0 {
+		return nil, nil
+	}
+	if userID == nil {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, "Custom domains are only available to signed-in users", nil)
+	}
+
+	domain, err := findDomain(s.db, *id, *userID)
+	if err != nil {
+		return nil, err
+	}
+	if !domain.IsVerified() {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, "Domain is not verified yet", nil)
+	}
+	return domain, nil
+}
+
+// campaignTemplateFor loads the campaign template a user's link should use, nil for 0
+func (s *urlService) campaignTemplateFor(id uint, userID *uint) (*models.CampaignTemplate, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	if userID == nil {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, "Campaign templates are only available to signed-in users", nil)
+	}
+	return findCampaignTemplate(s.db, id, *userID)
+}
+
+// Limits wrong password attempts per client and link - will be set from config
+var passwordLimiter = NewAttemptLimiter(5, 15*time.Minute)
+
+// SetPasswordAttemptLimit sets how many wrong passwords a client may try per link within window
+func SetPasswordAttemptLimit(maxAttempts int, window time.Duration) {
+	passwordLimiter = NewAttemptLimiter(maxAttempts, window)
+}
+
+// hashLinkPassword hashes a link password with bcrypt, like user passwords
+func hashLinkPassword(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to process password", err)
+	}
+	return string(hashedPassword), nil
+}
+
+func (s *urlService) CreateURL(req urlDTO.CreateURLRequest, userID *uint) (*models.URL, error) {
+	shortCode := req.ShortCode
+
 	// Validate original URL
-	if err := utils.ValidateURL(originalURL); err != nil {
+	if err := utils.ValidateURL(req.OriginalURL); err != nil {
 		return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid URL: %s", err.Error()), err)
 	}
 
 	// Normalize URL
-	normalizedURL := utils.NormalizeURL(originalURL)
+	normalizedURL := utils.NormalizeURL(req.OriginalURL)
+
+	if err := utils.ValidateURLWindow(req.ActivatesAt, req.ExpiresAt); err != nil {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, err.Error(), err)
+	}
+
+	var fallbackURL string
+	if req.FallbackURL != nil {
+		if err := utils.ValidateURL(*req.FallbackURL); err != nil {
+			return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid fallback URL: %s", err.Error()), err)
+		}
+		fallbackURL = utils.NormalizeURL(*req.FallbackURL)
+	}
+
+	domain, err := s.domainFor(req.DomainID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var domainID uint
+	if domain != nil {
+		domainID = domain.ID
+	}
+
+	var template *models.CampaignTemplate
+	if req.CampaignTemplateID != nil {
+		if template, err = s.campaignTemplateFor(*req.CampaignTemplateID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var utm models.UTMParams
+	if req.UTM != nil {
+		utm = models.NewUTMParams(*req.UTM)
+	}
+
+	// Links that don't choose a status follow the server default, even if it changes later
+	var redirectStatus int
+	if req.RedirectStatus != nil {
+		if !utils.IsValidRedirectStatus(*req.RedirectStatus) {
+			return nil, common.NewAppError(common.VALIDATION_ERROR, "Invalid redirect status: must be 301, 302, 307 or 308", nil)
+		}
+		redirectStatus = *req.RedirectStatus
+	}
 
-	// Generate short code if not provided
-	var finalShortCode string
 	if shortCode != nil {
 		if err := utils.ValidateShortCode(*shortCode); err != nil {
 			return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid short code: %s", err.Error()), err)
 		}
-		finalShortCode = *shortCode
-	} else {
-		generatedCode, err := utils.GenerateShortCode(utils.ShortCodeLength)
+		normalizedCode := utils.NormalizeShortCode(*shortCode)
+		shortCode = &normalizedCode
+
+		reservation, err := findReservation(s.db, *shortCode)
 		if err != nil {
-			return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to generate short code", err)
+			return nil, err
+		}
+		if reservation != nil {
+			err := reservationError(*shortCode, reservation)
+			return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid short code: %s", err.Error()), err)
 		}
-		finalShortCode = generatedCode
 	}
 
-	// Check if short code already exists
-	var existingURL models.URL
-	if err := s.db.Where("short_code = ?", finalShortCode).First(&existingURL).Error; err == nil {
-		if shortCode != nil {
-			return nil, common.NewAppError(common.SHORT_CODE_ALREADY_EXISTS, "Short code already exists", nil)
-		}
-		// If auto-generated, try again with a different code
-		generatedCode, err := utils.GenerateShortCode(utils.ShortCodeLength)
+	var passwordHash string
+	if req.Password != nil && *req.Password != "" {
+		hashed, err := hashLinkPassword(*req.Password)
 		if err != nil {
-			return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to generate unique short code", err)
+			return nil, err
 		}
-		finalShortCode = generatedCode
+		passwordHash = hashed
 	}
 
-	// Check if URL already exists for the same user
-	if userID != nil {
+	// Check if the custom short code already exists
+	if shortCode != nil {
+		var existingURL models.URL
+		if err := whereShortCode(s.db, domainID, *shortCode).First(&existingURL).Error; err == nil {
+			return nil, common.NewAppError(common.SHORT_CODE_ALREADY_EXISTS, "Short code already exists", nil)
+		}
+	}
+
+	// Check if URL already exists for the same user, unless the new link needs its own settings
+	if userID != nil && !hasLinkOptions(req) {
 		var existingUserURL models.URL
-		if err := s.db.Where("original_url = ? AND user_id = ?", normalizedURL, *userID).First(&existingUserURL).Error; err == nil {
+		if err := withDestinations(s.db).Where("original_url = ? AND user_id = ? AND domain_id = ?", normalizedURL, *userID, domainID).First(&existingUserURL).Error; err == nil {
 			return &existingUserURL, nil // Return existing URL
 		}
 	}
 
 	// Create URL
 	url := models.URL{
-		OriginalURL: normalizedURL,
-		ShortCode:   finalShortCode,
-		UserID:      userID,
-		ExpiresAt:   expiresAt,
-		ClickCount:  0,
-		IsActive:    true,
+		OriginalURL:    normalizedURL,
+		DomainID:       domainID,
+		UserID:         userID,
+		ActivatesAt:    req.ActivatesAt,
+		ExpiresAt:      req.ExpiresAt,
+		ClickCount:     0,
+		RedirectStatus: redirectStatus,
+		PasswordHash:   passwordHash,
+		MaxClicks:      req.MaxClicks,
+		FallbackURL:    fallbackURL,
+		UTM:            utm,
+		IsActive:       true,
+	}
+	if req.ForwardQuery != nil {
+		url.ForwardQuery = *req.ForwardQuery
+	}
+	if req.ForwardPath != nil {
+		url.ForwardPath = *req.ForwardPath
+	}
+	if template != nil {
+		url.CampaignTemplateID = &template.ID
 	}
 
-	if err := s.db.Create(&url).Error; err != nil {
-		if strings.Contains(err.Error(), "duplicate key value violates unique constraint \"idx_urls_short_code\"") {
-			return nil, common.NewAppError(common.SHORT_CODE_ALREADY_EXISTS, "Short code already exists", err)
-		}
-
-		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to create URL", err)
+	if err := s.insertURL(&url, shortCode); err != nil {
+		return nil, err
 	}
 
+	s.filter.Add(urlKey(&url))
+	s.negative.Remove(urlKey(&url))
+
+	url.CampaignTemplate = template
+	url.Domain = domain
+
 	return &url, nil
 }
 
-func (s *urlService) GetURLByShortCode(shortCode string) (*models.URL, error) {
+// insertURL creates a URL with the custom short code, or with generated codes until one is
+// free. Collisions are detected by the unique index rather than checked beforehand, so
+// concurrent creations can never claim the same code. Generated codes an admin reserved are
+// skipped.
+func (s *urlService) insertURL(url *models.URL, customCode *string) error {
+	if customCode != nil {
+		url.ShortCode = *customCode
+		if err := s.db.Create(url).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return common.NewAppError(common.SHORT_CODE_ALREADY_EXISTS, "Short code already exists", err)
+			}
+			return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to create URL", err)
+		}
+		return nil
+	}
+
+	return createWithGeneratedCode(s.generator, func(code string) error {
+		reservation, err := findReservation(s.db, code)
+		if err != nil {
+			return err
+		}
+		if reservation != nil {
+			return errShortCodeTaken
+		}
+
+		url.ID = 0
+		url.ShortCode = code
+		if err := s.db.Create(url).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errShortCodeTaken
+			}
+			return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to create URL", err)
+		}
+		return nil
+	})
+}
+
+// GetURLByShortCode resolves an active URL within its activation window, serving it from cache
+// when possible. Codes that were never created, or recently failed to resolve, are rejected
+// without a query. A link that exists but is unavailable is returned along with its error so
+// the caller can send visitors to a fallback.
+func (s *urlService) GetURLByShortCode(domainID uint, shortCode string) (*models.URL, error) {
+	key := shortCodeKey(domainID, shortCode)
+	if cached, ok := s.cache.Get(key); ok {
+		return cached, nil
+	}
+
+	if !s.filter.MightExist(key) || s.negative.Contains(key) {
+		return nil, common.NewAppError(common.URL_NOT_FOUND, "URL not found", nil)
+	}
+
 	var url models.URL
-	if err := s.db.Where("short_code = ? AND is_active = ?", shortCode, true).First(&url).Error; err != nil {
+	if err := whereShortCode(withDestinations(s.db), domainID, shortCode).First(&url).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
+			s.negative.Add(key)
 			return nil, common.NewAppError(common.URL_NOT_FOUND, "URL not found", err)
 		}
 		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to get URL", err)
 	}
 
-	// Check if URL has expired
-	if utils.IsURLExpired(url.ExpiresAt) {
-		return nil, common.NewAppError(common.URL_EXPIRED, "URL has expired", nil)
+	// Links that used up their clicks are deactivated, but report as gone rather than missing
+	if url.HasReachedClickLimit() {
+		return &url, common.NewAppError(common.URL_EXPIRED, "URL has reached its click limit", nil)
 	}
 
+	if !url.IsActive {
+		return &url, common.NewAppError(common.URL_NOT_FOUND, "URL not found", nil)
+	}
+
+	switch err := utils.CheckURLWindow(url.ActivatesAt, url.ExpiresAt); err {
+	case utils.ErrURLNotYetActive:
+		message := fmt.Sprintf("URL becomes active at %s", url.ActivatesAt.UTC().Format(time.RFC3339))
+		return &url, common.NewAppError(common.URL_NOT_YET_ACTIVE, message, err)
+	case utils.ErrURLExpired:
+		return &url, common.NewAppError(common.URL_EXPIRED, "URL has expired", err)
+	}
+
+	s.cache.Set(key, &url)
+
 	return &url, nil
 }
 
 func (s *urlService) GetURLByID(id uint, userID *uint) (*models.URL, error) {
 	var url models.URL
-	query := s.db.Where("id = ?", id)
+	query := withDestinations(s.db).Where("id = ?", id)
 
 	// If userID is provided, ensure user owns the URL
 	if userID != nil {
@@ -173,7 +424,8 @@ func (s *urlService) GetURLs(userID *uint, page, limit int, search *string, isAc
 	}
 
 	// Get paginated results
-	if err := query.Order(fmt.Sprintf("%s %s", sortBy, sortDir)).
+	if err := withDestinations(query).
+		Order(fmt.Sprintf("%s %s", sortBy, sortDir)).
 		Offset((page - 1) * limit).
 		Limit(limit).
 		Find(&urls).Error; err != nil {
@@ -183,7 +435,7 @@ func (s *urlService) GetURLs(userID *uint, page, limit int, search *string, isAc
 	return urls, total, nil
 }
 
-func (s *urlService) UpdateURL(id uint, userID *uint, originalURL *string, expiresAt *time.Time, isActive *bool) (*models.URL, error) {
+func (s *urlService) UpdateURL(id uint, userID *uint, req urlDTO.UpdateURLRequest) (*models.URL, error) {
 	// Get existing URL
 	url, err := s.GetURLByID(id, userID)
 	if err != nil {
@@ -191,32 +443,114 @@ func (s *urlService) UpdateURL(id uint, userID *uint, originalURL *string, expir
 	}
 
 	// Update fields if provided
-	if originalURL != nil {
-		if err := utils.ValidateURL(*originalURL); err != nil {
+	if req.OriginalURL != nil {
+		if err := utils.ValidateURL(*req.OriginalURL); err != nil {
 			return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid URL: %s", err.Error()), err)
 		}
-		url.OriginalURL = utils.NormalizeURL(*originalURL)
+		url.OriginalURL = utils.NormalizeURL(*req.OriginalURL)
 	}
 
-	if expiresAt != nil {
-		url.ExpiresAt = expiresAt
+	if req.ActivatesAt != nil {
+		url.ActivatesAt = req.ActivatesAt
 	}
 
-	if isActive != nil {
-		url.IsActive = *isActive
+	if req.ExpiresAt != nil {
+		url.ExpiresAt = req.ExpiresAt
+	}
+
+	if err := utils.ValidateURLWindow(url.ActivatesAt, url.ExpiresAt); err != nil {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, err.Error(), err)
+	}
+
+	if req.IsActive != nil {
+		url.IsActive = *req.IsActive
+	}
+
+	// 0 goes back to the server default
+	if req.RedirectStatus != nil {
+		if *req.RedirectStatus != 0 && !utils.IsValidRedirectStatus(*req.RedirectStatus) {
+			return nil, common.NewAppError(common.VALIDATION_ERROR, "Invalid redirect status: must be 0, 301, 302, 307 or 308", nil)
+		}
+		url.RedirectStatus = *req.RedirectStatus
+	}
+
+	// An empty password removes the protection
+	if req.Password != nil {
+		url.PasswordHash = ""
+		if *req.Password != "" {
+			hashed, err := hashLinkPassword(*req.Password)
+			if err != nil {
+				return nil, err
+			}
+			url.PasswordHash = hashed
+		}
+	}
+
+	// 0 removes the click limit
+	if req.MaxClicks != nil {
+		exhausted := url.HasReachedClickLimit()
+		url.MaxClicks = nil
+		if *req.MaxClicks > 0 {
+			url.MaxClicks = req.MaxClicks
+		}
+
+		// Raising or removing the limit revives a link that was deactivated by it
+		if exhausted && !url.HasReachedClickLimit() && req.IsActive == nil {
+			url.IsActive = true
+		}
+	}
+
+	// An empty fallback URL removes it
+	if req.FallbackURL != nil {
+		url.FallbackURL = ""
+		if *req.FallbackURL != "" {
+			if err := utils.ValidateURL(*req.FallbackURL); err != nil {
+				return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("Invalid fallback URL: %s", err.Error()), err)
+			}
+			url.FallbackURL = utils.NormalizeURL(*req.FallbackURL)
+		}
 	}
 
-	// Save changes
-	if err := s.db.Save(url).Error; err != nil {
+	if req.ForwardQuery != nil {
+		url.ForwardQuery = *req.ForwardQuery
+	}
+
+	if req.ForwardPath != nil {
+		url.ForwardPath = *req.ForwardPath
+	}
+
+	if req.UTM != nil {
+		url.UTM = models.NewUTMParams(*req.UTM)
+	}
+
+	// 0 removes the campaign template
+	if req.CampaignTemplateID != nil {
+		template, err := s.campaignTemplateFor(*req.CampaignTemplateID, url.UserID)
+		if err != nil {
+			return nil, err
+		}
+		url.CampaignTemplate = template
+		url.CampaignTemplateID = nil
+		if template != nil {
+			url.CampaignTemplateID = &template.ID
+		}
+	}
+
+	// Save changes. Counters are left alone as redirects may be updating them concurrently,
+	// and rules are managed through their own endpoints.
+	if err := s.db.Omit(clause.Associations, "click_count", "bot_click_count", "unique_clicks").Save(url).Error; err != nil {
 		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to update URL", err)
 	}
 
+	s.cache.Delete(urlKey(url))
+	s.negative.Remove(urlKey(url))
+
 	return url, nil
 }
 
 func (s *urlService) DeleteURL(id uint, userID *uint) error {
 	// Check if URL exists and user has permission
-	_, err := s.GetURLByID(id, userID)
+	url, err := s.GetURLByID(id, userID)
 	if err != nil {
 		return err
 	}
@@ -226,13 +560,8 @@ func (s *urlService) DeleteURL(id uint, userID *uint) error {
 		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to delete URL", err)
 	}
 
-	return nil
-}
+	s.cache.Delete(urlKey(url))
 
-func (s *urlService) IncrementClickCount(shortCode string) error {
-	if err := s.db.Model(&models.URL{}).Where("short_code = ?", shortCode).Update("click_count", gorm.Expr("click_count + 1")).Error; err != nil {
-		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to increment click count", err)
-	}
 	return nil
 }
 
@@ -245,3 +574,64 @@ func (s *urlService) GetURLStats(id uint, userID *uint) (*models.URL, error) {
 
 	return url, nil
 }
+
+// UnlockURL checks the password of a protected URL. Clients are blocked for a while
+// after too many wrong passwords for the same link.
+func (s *urlService) UnlockURL(url *models.URL, password, clientIP string) error {
+	if !url.HasPassword() {
+		return nil
+	}
+
+	attemptKey := fmt.Sprintf("%d:%s", url.ID, clientIP)
+	if !passwordLimiter.Reserve(attemptKey) {
+		return common.NewAppError(common.TOO_MANY_REQUESTS, "Too many wrong passwords, please try again later", nil)
+	}
+
+	// The reserved attempt stays counted as a failure unless the password matches
+	if err := bcrypt.CompareHashAndPassword([]byte(url.PasswordHash), []byte(password)); err != nil {
+		return common.NewAppError(common.INVALID_CREDENTIALS, "Incorrect password", err)
+	}
+
+	passwordLimiter.Reset(attemptKey)
+	return nil
+}
+
+// ClaimClick consumes one click of a click-limited URL. The check and increment happen in a
+// single conditional UPDATE so concurrent redirects can never exceed the limit; the claim
+// that uses up the last click also deactivates the link.
+func (s *urlService) ClaimClick(url *models.URL) error {
+	if url.MaxClicks == nil {
+		return nil
+	}
+
+	result := s.db.Model(&models.URL{}).
+		Where("id = ? AND is_active = ? AND max_clicks IS NOT NULL AND click_count < max_clicks", url.ID, true).
+		Updates(map[string]interface{}{
+			"click_count": gorm.Expr("click_count + 1"),
+			"is_active":   gorm.Expr("click_count + 1 < max_clicks"),
+		})
+	if result.Error != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to record click", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return common.NewAppError(common.URL_EXPIRED, "URL has reached its click limit", nil)
+	}
+	return nil
+}
+
+// FallbackURLFor returns where visitors of an unavailable URL should be sent: the link's own
+// fallback, else its owner's default, else an empty string
+func (s *urlService) FallbackURLFor(url *models.URL) string {
+	if url.FallbackURL != "" || url.UserID == nil {
+		return url.FallbackURL
+	}
+
+	var defaultFallbackURL string
+	if err := s.db.Model(&models.User{}).Select("default_fallback_url").
+		Where("id = ?", *url.UserID).Limit(1).Scan(&defaultFallbackURL).Error; err != nil {
+		logger.Warnf("Failed to get default fallback URL for user %d: %v", *url.UserID, err)
+		return ""
+	}
+	return defaultFallbackURL
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/tinwritescode/myapp/internal/database"
+	"github.com/tinwritescode/myapp/internal/dto/common"
+	urlDTO "github.com/tinwritescode/myapp/internal/dto/url"
+	"github.com/tinwritescode/myapp/internal/models"
+	"gorm.io/gorm"
+)
+
+// MaxVariantsPerURL caps how many destinations a URL can rotate between
+const MaxVariantsPerURL = 10
+
+type URLVariantService interface {
+	GetVariants(urlID uint, userID *uint) ([]models.URLVariant, error)
+	CreateVariant(urlID uint, userID *uint, req urlDTO.CreateURLVariantRequest) (*models.URLVariant, error)
+	UpdateVariant(urlID, variantID uint, userID *uint, req urlDTO.UpdateURLVariantRequest) (*models.URLVariant, error)
+	DeleteVariant(urlID, variantID uint, userID *uint) error
+}
+
+type urlVariantService struct {
+	db         *gorm.DB
+	cache      URLCache
+	urlService URLService
+}
+
+var (
+	urlVariantServiceInstance URLVariantService
+)
+
+func NewURLVariantService() URLVariantService {
+	return &urlVariantService{
+		db:         database.GetDB(),
+		cache:      urlCache,
+		urlService: GetURLService(),
+	}
+}
+
+func GetURLVariantService() URLVariantService {
+	if urlVariantServiceInstance == nil {
+		urlVariantServiceInstance = NewURLVariantService()
+	}
+	return urlVariantServiceInstance
+}
+
+// orderedVariants preloads a URL's variants in creation order
+func orderedVariants(db *gorm.DB) *gorm.DB {
+	return db.Order("id ASC")
+}
+
+func (s *urlVariantService) GetVariants(urlID uint, userID *uint) ([]models.URLVariant, error) {
+	url, err := s.urlService.GetURLByID(urlID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return url.Variants, nil
+}
+
+func (s *urlVariantService) CreateVariant(urlID uint, userID *uint, req urlDTO.CreateURLVariantRequest) (*models.URLVariant, error) {
+	url, err := s.urlService.GetURLByID(urlID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(url.Variants) >= MaxVariantsPerURL {
+		return nil, common.NewAppError(common.VALIDATION_ERROR, fmt.Sprintf("A URL can have at most %d variants", MaxVariantsPerURL), nil)
+	}
+
+	targetURL, err := normalizeTargetURL(req.TargetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	variant := models.URLVariant{
+		URLID:     url.ID,
+		TargetURL: targetURL,
+		Weight:    1,
+	}
+	if req.Weight != nil {
+		variant.Weight = *req.Weight
+	}
+	if req.Label != nil {
+		variant.Label = *req.Label
+	}
+
+	if err := s.db.Create(&variant).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to create variant", err)
+	}
+
+	s.cache.Delete(urlKey(url))
+
+	return &variant, nil
+}
+
+func (s *urlVariantService) UpdateVariant(urlID, variantID uint, userID *uint, req urlDTO.UpdateURLVariantRequest) (*models.URLVariant, error) {
+	url, variant, err := s.getVariant(urlID, variantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TargetURL != nil {
+		if variant.TargetURL, err = normalizeTargetURL(*req.TargetURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Weight != nil {
+		variant.Weight = *req.Weight
+	}
+
+	if req.Label != nil {
+		variant.Label = *req.Label
+	}
+
+	if err := s.db.Save(variant).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to update variant", err)
+	}
+
+	s.cache.Delete(urlKey(url))
+
+	return variant, nil
+}
+
+func (s *urlVariantService) DeleteVariant(urlID, variantID uint, userID *uint) error {
+	url, variant, err := s.getVariant(urlID, variantID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Delete(variant).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "Failed to delete variant", err)
+	}
+
+	s.cache.Delete(urlKey(url))
+
+	return nil
+}
+
+// getVariant finds a variant among those of a URL owned by the user
+func (s *urlVariantService) getVariant(urlID, variantID uint, userID *uint) (*models.URL, *models.URLVariant, error) {
+	url, err := s.urlService.GetURLByID(urlID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range url.Variants {
+		if url.Variants[i].ID == variantID {
+			return url, &url.Variants[i], nil
+		}
+	}
+
+	return nil, nil, common.NewAppError(common.NOT_FOUND, "Variant not found", nil)
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/tinwritescode/myapp/internal/database"
 	"github.com/tinwritescode/myapp/internal/dto/common"
+	userDTO "github.com/tinwritescode/myapp/internal/dto/user"
 	"github.com/tinwritescode/myapp/internal/models"
+	"github.com/tinwritescode/myapp/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -20,6 +22,8 @@ type UserService interface {
 	RevokeRefreshToken(refreshToken string) error
 	GetUserByID(id uint) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
+	UpdateSettings(id uint, req userDTO.UpdateUserSettingsRequest) (*models.User, error)
+	GrantAdmin(userIDs []uint) error
 }
 
 type userService struct {
@@ -172,6 +176,44 @@ func (s *userService) GetUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// UpdateSettings updates the link settings of a user
+func (s *userService) UpdateSettings(id uint, req userDTO.UpdateUserSettingsRequest) (*models.User, error) {
+	existingUser, err := s.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty fallback URL removes it
+	if req.DefaultFallbackURL != nil {
+		existingUser.DefaultFallbackURL = ""
+		if *req.DefaultFallbackURL != "" {
+			if err := utils.ValidateURL(*req.DefaultFallbackURL); err != nil {
+				return nil, common.NewAppError(common.VALIDATION_ERROR, "invalid fallback URL: "+err.Error(), err)
+			}
+			existingUser.DefaultFallbackURL = utils.NormalizeURL(*req.DefaultFallbackURL)
+		}
+	}
+
+	if err := s.db.Model(existingUser).Update("default_fallback_url", existingUser.DefaultFallbackURL).Error; err != nil {
+		return nil, common.NewAppError(common.INTERNAL_SERVER_ERROR, "failed to update settings", err)
+	}
+
+	return existingUser, nil
+}
+
+// GrantAdmin makes the users with the given IDs admins. Admin rights are never revoked here,
+// so removing an ID from the list doesn't demote the user.
+func (s *userService) GrantAdmin(userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	if err := s.db.Model(&models.User{}).Where("id IN ? AND is_admin = ?", userIDs, false).Update("is_admin", true).Error; err != nil {
+		return common.NewAppError(common.INTERNAL_SERVER_ERROR, "failed to grant admin rights", err)
+	}
+	return nil
+}
+
 func (s *userService) generateJWT(user *models.User) (string, error) {
 	expirationTime := time.Now().Add(24 * time.Hour)
 	claims := &Claims{
@@ -1,20 +1,32 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/tinwritescode/myapp/internal/config"
 	"github.com/tinwritescode/myapp/internal/database"
+	"github.com/tinwritescode/myapp/internal/handlers"
 	"github.com/tinwritescode/myapp/internal/middleware"
 	"github.com/tinwritescode/myapp/internal/models"
 	"github.com/tinwritescode/myapp/internal/routes"
 	"github.com/tinwritescode/myapp/internal/service"
+	"github.com/tinwritescode/myapp/pkg/geoip"
 	"github.com/tinwritescode/myapp/pkg/logger"
+	"github.com/tinwritescode/myapp/pkg/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
+// lowerShortCodeIndex keeps short codes unique ignoring case in case-insensitive mode
+const lowerShortCodeIndex = "idx_urls_lower_short_code_domain"
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
@@ -31,13 +43,124 @@ func main() {
 	}
 
 	// Run database migrations
-	if err := database.AutoMigrate(&models.User{}, &models.Account{}, &models.URL{}, &models.RefreshToken{}); err != nil {
+	if err := database.AutoMigrate(&models.User{}, &models.Account{}, &models.CampaignTemplate{}, &models.Domain{}, &models.URL{}, &models.RefreshToken{}, &models.ClickEvent{}, &models.URLVisitor{}, &models.RedirectRule{}, &models.URLVariant{}, &models.ReservedCode{}); err != nil {
+		logger.Fatal("Failed to run migrations:", err)
+	}
+
+	// Short codes used to be globally unique; they are now unique per domain
+	if err := database.DropIndex(&models.URL{}, "idx_urls_short_code"); err != nil {
+		logger.Fatal("Failed to run migrations:", err)
+	}
+
+	// Load GeoIP database for click attribution (optional)
+	geoLookup, err := geoip.Open(cfg.Analytics.GeoIPDatabasePath)
+	if err != nil {
+		logger.Warnf("GeoIP attribution disabled: %v", err)
+		geoLookup = geoip.NewNoopLookup()
+	}
+	defer geoLookup.Close()
+	service.SetGeoIPLookup(geoLookup)
+	service.SetVisitorSalt(cfg.Analytics.VisitorSalt)
+
+	// Configure redirect defaults
+	if !utils.IsValidRedirectStatus(cfg.Redirect.DefaultStatus) {
+		logger.Fatalf("Invalid REDIRECT_DEFAULT_STATUS %d: must be 301, 302, 307 or 308", cfg.Redirect.DefaultStatus)
+	}
+	service.SetDefaultRedirectStatus(cfg.Redirect.DefaultStatus)
+	service.SetPasswordAttemptLimit(cfg.Redirect.PasswordMaxAttempts, cfg.Redirect.PasswordLockout)
+
+	handlers.SetBulkCreateLimit(cfg.Server.BulkCreateLimit)
+
+	if cfg.Redirect.FallbackPagePath != "" {
+		if err := handlers.SetFallbackPage(cfg.Redirect.FallbackPagePath); err != nil {
+			logger.Fatalf("Failed to load fallback page %s: %v", cfg.Redirect.FallbackPagePath, err)
+		}
+	}
+
+	// Promote the configured admins
+	if err := service.GetUserService().GrantAdmin(cfg.Server.AdminUserIDs); err != nil {
+		logger.Fatalf("Failed to grant admin rights: %v", err)
+	}
+
+	// Short links on the server's own domain are reported with this base URL
+	if cfg.Server.BaseURL == "" && cfg.Server.Environment == "production" {
+		logger.Fatal("BASE_URL must be set in production")
+	}
+	models.SetShortURLBase(cfg.Server.BaseURL)
+
+	// Short code length, alphabet and word lists
+	alphabet := cfg.ShortCode.Alphabet
+	if preset, ok := utils.ShortCodeAlphabets[alphabet]; ok {
+		alphabet = preset
+	}
+	if err := utils.SetShortCodePolicy(utils.ShortCodePolicy{
+		Length:          cfg.ShortCode.Length,
+		MinLength:       cfg.ShortCode.MinLength,
+		MaxLength:       cfg.ShortCode.MaxLength,
+		Alphabet:        alphabet,
+		Reserved:        cfg.ShortCode.Reserved,
+		Blocklist:       cfg.ShortCode.Blocklist,
+		CaseInsensitive: cfg.ShortCode.CaseInsensitive,
+	}); err != nil {
+		logger.Fatalf("Invalid short code configuration: %v", err)
+	}
+
+	// Case-insensitive codes must also be unique ignoring case. Creating the index fails if
+	// existing codes on a domain differ only in case, which must be renamed first.
+	if cfg.ShortCode.CaseInsensitive {
+		if err := database.CreateUniqueIndex(lowerShortCodeIndex, "urls", "lower(short_code), domain_id"); err != nil {
+			logger.Fatalf("Failed to enable case-insensitive short codes: %v", err)
+		}
+	} else if err := database.DropIndex(&models.URL{}, lowerShortCodeIndex); err != nil {
 		logger.Fatal("Failed to run migrations:", err)
 	}
 
+	// Choose how short codes are generated for links without a custom one
+	switch cfg.ShortCode.Strategy {
+	case service.ShortCodeStrategyRandom:
+	case service.ShortCodeStrategySequence:
+		generator, err := service.NewSequenceShortCodeGenerator(database.GetDB())
+		if err != nil {
+			logger.Fatalf("Failed to set up short code sequence: %v", err)
+		}
+		service.SetShortCodeGenerator(generator)
+	default:
+		logger.Fatalf("Invalid SHORT_CODE_STRATEGY %q, expected %q or %q",
+			cfg.ShortCode.Strategy, service.ShortCodeStrategyRandom, service.ShortCodeStrategySequence)
+	}
+
+	// Cache short code lookups for redirects
+	if cfg.Cache.URLCacheSize > 0 {
+		service.SetURLCache(service.NewLRUURLCache(cfg.Cache.URLCacheSize, cfg.Cache.URLCacheTTL))
+	}
+
+	// Answer lookups for unknown short codes without touching the database
+	if cfg.Cache.NegativeCacheSize > 0 {
+		service.SetNegativeCache(service.NewNegativeCache(cfg.Cache.NegativeCacheSize, cfg.Cache.NegativeCacheTTL))
+	}
+	if cfg.Cache.ShortCodeFilterEnabled {
+		filter := service.NewShortCodeFilter(0.01)
+		filter.StartRebuilding(database.GetDB(), cfg.Cache.ShortCodeFilterRebuildInterval)
+		service.SetShortCodeFilter(filter)
+	}
+
+	// Start the background click recorder
+	clickRecorder := service.InitClickRecorder(service.ClickRecorderOptions{
+		QueueSize:     cfg.Analytics.ClickQueueSize,
+		BatchSize:     cfg.Analytics.ClickBatchSize,
+		FlushInterval: cfg.Analytics.ClickFlushInterval,
+	})
+
 	// Setup Gin router
 	r := gin.Default()
 
+	// Only trust forwarded client IPs from known proxies, so clients can't pick their own IP
+	// to get around per-client limits
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	r.TrustedPlatform = cfg.Server.TrustedPlatform
+
 	// Configure CORS
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:5173", "http://localhost:5174", "https://myapp-frontend.fly.dev"},
@@ -51,10 +174,40 @@ func main() {
 	// Setup routes
 	routes.SetupRoutes(r)
 
+	// Links must not shadow routes, including ones added later
+	utils.ReserveShortCodes(routes.ReservedShortCodes(r)...)
+
 	// Start server
 	port := ":" + cfg.Server.Port
-	logger.Infof("Server starting on port %s", port)
-	if err := r.Run(port); err != nil {
-		logger.Fatal("Failed to start server:", err)
+	srv := &http.Server{
+		Addr:    port,
+		Handler: r,
+	}
+
+	go func() {
+		logger.Infof("Server starting on port %s", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// Wait for an interrupt, then stop accepting requests and drain queued clicks
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	logger.Info("Shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Server forced to shutdown:", err)
 	}
+
+	if err := clickRecorder.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to drain click queue:", err)
+	}
+
+	stats := clickRecorder.Stats()
+	logger.Infof("Click recorder stopped: %d flushed, %d failed, %d dropped", stats.Flushed, stats.Failed, stats.Dropped)
 }
@@ -0,0 +1,104 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Location represents the geographic attribution of an IP address
+type Location struct {
+	Country string // ISO 3166-1 alpha-2 code, e.g. "US"
+	City    string // English city name
+}
+
+// Lookup resolves IP addresses to locations
+type Lookup interface {
+	Lookup(ip string) Location
+	Close() error
+}
+
+// cityRecord mirrors the subset of the GeoIP2/GeoLite2 City schema we use.
+// Country-only databases simply leave City empty.
+type cityRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+}
+
+type mmdbLookup struct {
+	reader *maxminddb.Reader
+}
+
+type noopLookup struct{}
+
+// Open loads a MaxMind-format (MMDB) database from disk.
+// An empty path returns a lookup that never resolves, so GeoIP stays optional.
+func Open(path string) (Lookup, error) {
+	if path == "" {
+		return NewNoopLookup(), nil
+	}
+
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+
+	return &mmdbLookup{reader: reader}, nil
+}
+
+// NewNoopLookup returns a lookup that always returns an empty location
+func NewNoopLookup() Lookup {
+	return noopLookup{}
+}
+
+// Lookup returns the location for ip, or an empty location if it is unknown
+func (l *mmdbLookup) Lookup(ip string) Location {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return Location{}
+	}
+
+	var record cityRecord
+	if err := l.reader.Lookup(parsedIP, &record); err != nil {
+		return Location{}
+	}
+
+	return Location{
+		Country: record.Country.ISOCode,
+		City:    record.City.Names["en"],
+	}
+}
+
+// Close releases the underlying database
+func (l *mmdbLookup) Close() error {
+	return l.reader.Close()
+}
+
+func (noopLookup) Lookup(string) Location {
+	return Location{}
+}
+
+func (noopLookup) Close() error {
+	return nil
+}
+
+// NormalizeCountryCode returns an ISO 3166-1 alpha-2 code in upper case, reporting
+// false if value is not two ASCII letters
+func NormalizeCountryCode(value string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if len(code) != 2 {
+		return "", false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+	return code, true
+}
@@ -0,0 +1,151 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+
+	encoder "github.com/skip2/go-qrcode"
+)
+
+// Options controls how a QR code is drawn
+type Options struct {
+	// Size is the width and height of the image in pixels
+	Size int
+	// Level is the error correction level: L, M, Q or H
+	Level      string
+	Foreground color.RGBA
+	Background color.RGBA
+	// QuietZone is the width of the blank border in modules; scanners expect at least 4
+	QuietZone int
+}
+
+// ParseColor parses an RRGGBB hex colour, with or without a leading #
+func ParseColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid colour %q: expected RRGGBB", value)
+	}
+
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid colour %q: expected RRGGBB", value)
+	}
+
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}, nil
+}
+
+// recoveryLevel maps an error correction level name to the encoder's level
+func recoveryLevel(level string) (encoder.RecoveryLevel, error) {
+	switch strings.ToUpper(level) {
+	case "L":
+		return encoder.Low, nil
+	case "M", "":
+		return encoder.Medium, nil
+	case "Q":
+		return encoder.High, nil
+	case "H":
+		return encoder.Highest, nil
+	default:
+		return 0, fmt.Errorf("invalid error correction level %q: must be L, M, Q or H", level)
+	}
+}
+
+// modules encodes content and returns its modules, surrounded by the quiet zone.
+// modules[y][x] is true for dark modules.
+func modules(content string, opts Options) ([][]bool, error) {
+	level, err := recoveryLevel(opts.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := encoder.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+	code.DisableBorder = true
+	symbol := code.Bitmap()
+
+	size := len(symbol) + 2*opts.QuietZone
+	bitmap := make([][]bool, size)
+	for y := range bitmap {
+		bitmap[y] = make([]bool, size)
+	}
+	for y, row := range symbol {
+		copy(bitmap[y+opts.QuietZone][opts.QuietZone:], row)
+	}
+	return bitmap, nil
+}
+
+// PNG renders content as a QR code PNG of opts.Size pixels. Modules are drawn at a whole
+// number of pixels each, centred, so the code stays sharp for scanners.
+func PNG(content string, opts Options) ([]byte, error) {
+	bitmap, err := modules(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := opts.Size / len(bitmap)
+	if scale < 1 {
+		return nil, fmt.Errorf("size %dpx is too small for a %d module code", opts.Size, len(bitmap))
+	}
+	offset := (opts.Size - scale*len(bitmap)) / 2
+
+	palette := color.Palette{opts.Background, opts.Foreground}
+	img := image.NewPaletted(image.Rect(0, 0, opts.Size, opts.Size), palette)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders content as a QR code SVG displayed at opts.Size pixels
+func SVG(content string, opts Options) ([]byte, error) {
+	bitmap, err := modules(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, len(bitmap), len(bitmap))
+	fmt.Fprintf(&buf, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(opts.Background))
+	fmt.Fprintf(&buf, `<path fill="%s" d="`, hexColor(opts.Foreground))
+	for y, row := range bitmap {
+		// Draw each run of dark modules as a single rectangle
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&buf, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes(), nil
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// BloomFilter is a space-efficient set that may report false positives but never
+// false negatives. It is not safe for concurrent use.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64 // number of bits
+	hashes uint64 // number of hash functions
+}
+
+// NewBloomFilter sizes a filter for the expected number of items and target false positive rate
+func NewBloomFilter(expectedItems int, falsePositiveRate float64) *BloomFilter {
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	n := float64(expectedItems)
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/n*math.Ln2)))
+
+	return &BloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// Add inserts an item into the filter
+func (b *BloomFilter) Add(item string) {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MightContain reports whether an item may be in the filter.
+// A false result means the item was definitely never added.
+func (b *BloomFilter) MightContain(item string) bool {
+	h1, h2 := bloomHashes(item)
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives two hashes for double hashing (Kirsch-Mitzenmacher)
+func bloomHashes(item string) (uint64, uint64) {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(item))
+	h1 := hasher.Sum64()
+
+	hasher.Write([]byte{0xff})
+	h2 := hasher.Sum64() | 1 // odd so successive probes differ
+
+	return h1, h2
+}
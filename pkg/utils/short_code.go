@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Short code alphabets
+const (
+	// AlphanumericAlphabet orders digits before letters so encoded numbers sort like the numbers
+	AlphanumericAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// UnambiguousAlphabet leaves out characters that look alike when printed: 0/O/o and 1/l/I
+	UnambiguousAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz"
+)
+
+// ShortCodeAlphabets names the built-in alphabets so config can refer to them
+var ShortCodeAlphabets = map[string]string{
+	"alphanumeric": AlphanumericAlphabet,
+	"unambiguous":  UnambiguousAlphabet,
+}
+
+// DefaultReservedShortCodes can't be used as custom short codes
+var DefaultReservedShortCodes = []string{
+	"admin", "api", "www", "mail", "ftp", "blog", "shop", "help",
+	"about", "contact", "terms", "privacy", "login", "register",
+	"dashboard", "profile", "settings", "logout", "search",
+}
+
+// DefaultShortCodeBlocklist lists offensive words short codes must not contain. Words that
+// are common inside innocent ones ("ass" in "class") are left out.
+var DefaultShortCodeBlocklist = []string{
+	"fuck", "shit", "cunt", "bitch", "cock", "dick", "piss", "slut", "whore", "fag",
+	"nigg", "porn", "nazi", "twat", "wank", "bastard", "pussy", "penis", "vagina", "tits",
+}
+
+// ShortCodePolicy controls how short codes are generated and which custom codes are accepted
+type ShortCodePolicy struct {
+	// Length of generated codes, which grow up to MaxLength when collisions repeat
+	Length    int
+	MinLength int
+	MaxLength int
+	// Alphabet generated codes are drawn from. Custom codes may use any alphanumeric characters.
+	Alphabet string
+	// Reserved codes can't be used as custom codes, ignoring case
+	Reserved []string
+	// Codes containing a blocklisted word are rejected, ignoring case
+	Blocklist []string
+	// CaseInsensitive stores codes lowercased and resolves them ignoring case, so codes read
+	// aloud or typed from print still work. Generated codes only use lowercase letters.
+	CaseInsensitive bool
+}
+
+// DefaultShortCodePolicy returns the policy used until SetShortCodePolicy is called
+func DefaultShortCodePolicy() ShortCodePolicy {
+	return ShortCodePolicy{
+		Length:    6,
+		MinLength: 3,
+		MaxLength: 8,
+		Alphabet:  AlphanumericAlphabet,
+		Reserved:  DefaultReservedShortCodes,
+		Blocklist: DefaultShortCodeBlocklist,
+	}
+}
+
+// Short code policy - will be set from config
+var shortCodePolicy = DefaultShortCodePolicy()
+
+var alphanumericRegex = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// SetShortCodePolicy validates and sets the short code policy. Reserved and blocklisted words
+// are matched ignoring case.
+func SetShortCodePolicy(policy ShortCodePolicy) error {
+	if policy.MinLength < 1 || policy.MinLength > policy.Length || policy.Length > policy.MaxLength {
+		return fmt.Errorf("short code lengths must satisfy 1 <= min (%d) <= length (%d) <= max (%d)",
+			policy.MinLength, policy.Length, policy.MaxLength)
+	}
+	if len(policy.Alphabet) < 2 || !alphanumericRegex.MatchString(policy.Alphabet) {
+		return fmt.Errorf("short code alphabet must have at least 2 alphanumeric characters")
+	}
+	for i := range len(policy.Alphabet) {
+		if strings.IndexByte(policy.Alphabet, policy.Alphabet[i]) != i {
+			return fmt.Errorf("short code alphabet repeats %q", policy.Alphabet[i])
+		}
+	}
+	if policy.CaseInsensitive {
+		policy.Alphabet = lowerAlphabet(policy.Alphabet)
+	}
+
+	policy.Reserved = lowerWords(policy.Reserved)
+	policy.Blocklist = lowerWords(policy.Blocklist)
+	shortCodePolicy = policy
+	return nil
+}
+
+// GetShortCodePolicy returns the current short code policy
+func GetShortCodePolicy() ShortCodePolicy {
+	return shortCodePolicy
+}
+
+// lowerAlphabet lowercases an alphabet, dropping letters that then appear twice
+func lowerAlphabet(alphabet string) string {
+	var lowered strings.Builder
+	for _, char := range strings.ToLower(alphabet) {
+		if !strings.ContainsRune(lowered.String(), char) {
+			lowered.WriteRune(char)
+		}
+	}
+	return lowered.String()
+}
+
+func lowerWords(words []string) []string {
+	lowered := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			lowered = append(lowered, word)
+		}
+	}
+	return lowered
+}
+
+// maxBlockedShortCodes bounds how many blocklisted codes GenerateShortCode draws in a row
+const maxBlockedShortCodes = 100
+
+// GenerateShortCode generates a random short code of the given length from the policy's
+// alphabet, skipping codes that contain blocklisted words. Lengths outside the policy's
+// bounds fall back to its default length.
+func GenerateShortCode(length int) (string, error) {
+	policy := shortCodePolicy
+	if length < policy.MinLength || length > policy.MaxLength {
+		length = policy.Length
+	}
+
+	alphabetSize := big.NewInt(int64(len(policy.Alphabet)))
+	code := make([]byte, length)
+	for range maxBlockedShortCodes {
+		for i := range code {
+			index, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return "", fmt.Errorf("failed to generate random index: %w", err)
+			}
+			code[i] = policy.Alphabet[index.Int64()]
+		}
+
+		if !IsBlockedShortCode(string(code)) {
+			return string(code), nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate a short code without blocked words")
+}
+
+// EncodeShortCode encodes n in the base of the policy's alphabet
+func EncodeShortCode(n uint64) string {
+	alphabet := shortCodePolicy.Alphabet
+	base := uint64(len(alphabet))
+	if n == 0 {
+		return alphabet[:1]
+	}
+
+	var encoded []byte
+	for n > 0 {
+		encoded = append(encoded, alphabet[n%base])
+		n /= base
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// NormalizeShortCode returns the form a short code is stored and looked up in: lowercased
+// when short codes are case-insensitive, unchanged otherwise
+func NormalizeShortCode(shortCode string) string {
+	if shortCodePolicy.CaseInsensitive {
+		return strings.ToLower(shortCode)
+	}
+	return shortCode
+}
+
+// IsBlockedShortCode reports whether a short code contains a blocklisted word
+func IsBlockedShortCode(shortCode string) bool {
+	lowerCode := strings.ToLower(shortCode)
+	for _, word := range shortCodePolicy.Blocklist {
+		if strings.Contains(lowerCode, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReservedShortCode reports whether a short code is on the reserved list, ignoring case
+func IsReservedShortCode(shortCode string) bool {
+	return slices.Contains(shortCodePolicy.Reserved, strings.ToLower(shortCode))
+}
+
+// ValidateShortCode validates if a short code meets the requirements
+func ValidateShortCode(shortCode string) error {
+	policy := shortCodePolicy
+	if shortCode == "" {
+		return fmt.Errorf("short code cannot be empty")
+	}
+
+	if len(shortCode) < policy.MinLength || len(shortCode) > policy.MaxLength {
+		return fmt.Errorf("short code must be between %d and %d characters", policy.MinLength, policy.MaxLength)
+	}
+
+	// Check if it contains only alphanumeric characters
+	if !alphanumericRegex.MatchString(shortCode) {
+		return fmt.Errorf("short code must contain only alphanumeric characters")
+	}
+
+	if IsReservedShortCode(shortCode) {
+		return fmt.Errorf("short code '%s' is reserved", shortCode)
+	}
+
+	if IsBlockedShortCode(shortCode) {
+		return fmt.Errorf("short code '%s' contains a blocked word", shortCode)
+	}
+
+	return nil
+}
+
+// ReserveShortCodes adds codes to the policy's reserved list
+func ReserveShortCodes(codes ...string) {
+	shortCodePolicy.Reserved = slices.Concat(shortCodePolicy.Reserved, lowerWords(codes))
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// IntervalHour buckets time series data by hour
+	IntervalHour = "hour"
+	// IntervalDay buckets time series data by calendar day
+	IntervalDay = "day"
+	// IntervalWeek buckets time series data by ISO week (starting Monday)
+	IntervalWeek = "week"
+
+	// MaxTimeSeriesBuckets is the maximum number of buckets a single time series may span
+	MaxTimeSeriesBuckets = 2000
+)
+
+// TruncateToInterval returns the start of the bucket containing t in the given location
+func TruncateToInterval(t time.Time, interval string, loc *time.Location) time.Time {
+	t = t.In(loc)
+	switch interval {
+	case IntervalHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+	case IntervalWeek:
+		// Postgres date_trunc('week') and ISO weeks start on Monday
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+}
+
+// NextInterval returns the start of the bucket following the bucket starting at t.
+// Days and weeks are advanced on the calendar so DST transitions are respected.
+func NextInterval(t time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalHour:
+		return t.Add(time.Hour)
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// DefaultTimeSeriesRange returns the default lookback window for an interval
+func DefaultTimeSeriesRange(interval string) time.Duration {
+	switch interval {
+	case IntervalHour:
+		return 24 * time.Hour
+	case IntervalWeek:
+		return 12 * 7 * 24 * time.Hour
+	default:
+		return 30 * 24 * time.Hour
+	}
+}
+
+// TimeSeriesBuckets returns the start of every bucket overlapping [from, to)
+func TimeSeriesBuckets(from, to time.Time, interval string, loc *time.Location) ([]time.Time, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	var buckets []time.Time
+	for bucket := TruncateToInterval(from, interval, loc); bucket.Before(to); bucket = NextInterval(bucket, interval) {
+		buckets = append(buckets, bucket)
+		if len(buckets) > MaxTimeSeriesBuckets {
+			return nil, fmt.Errorf("time range spans more than %d %s buckets", MaxTimeSeriesBuckets, interval)
+		}
+	}
+
+	return buckets, nil
+}
@@ -1,58 +1,16 @@
 package utils
 
 import (
-	"crypto/rand"
-	"encoding/base64"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
 	"time"
 )
 
-const (
-	// ShortCodeLength is the default length for generated short codes
-	ShortCodeLength = 6
-	// MaxShortCodeLength is the maximum length for custom short codes
-	MaxShortCodeLength = 8
-	// MinShortCodeLength is the minimum length for short codes
-	MinShortCodeLength = 3
-)
-
-// GenerateShortCode generates a random short code of specified length
-func GenerateShortCode(length int) (string, error) {
-	if length < MinShortCodeLength || length > MaxShortCodeLength {
-		length = ShortCodeLength
-	}
-
-	// Generate random bytes
-	bytes := make([]byte, length)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", fmt.Errorf("failed to generate random bytes: %w", err)
-	}
-
-	// Convert to base64 and clean up
-	shortCode := base64.URLEncoding.EncodeToString(bytes)
-	shortCode = strings.TrimRight(shortCode, "=")
-	shortCode = shortCode[:length]
-
-	// Ensure it's alphanumeric
-	shortCode = regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString(shortCode, "")
-
-	// If too short after cleaning, pad with random characters
-	if len(shortCode) < length {
-		additional := make([]byte, length-len(shortCode))
-		if _, err := rand.Read(additional); err != nil {
-			return "", fmt.Errorf("failed to generate additional random bytes: %w", err)
-		}
-		additionalStr := base64.URLEncoding.EncodeToString(additional)
-		additionalStr = regexp.MustCompile(`[^a-zA-Z0-9]`).ReplaceAllString(additionalStr, "")
-		shortCode += additionalStr[:length-len(shortCode)]
-	}
-
-	return shortCode, nil
-}
-
 // ValidateURL validates if a string is a valid URL
 func ValidateURL(rawURL string) error {
 	if rawURL == "" {
@@ -116,49 +74,56 @@ func isMaliciousURL(parsedURL *url.URL) bool {
 	return false
 }
 
-// ValidateShortCode validates if a short code meets the requirements
-func ValidateShortCode(shortCode string) error {
-	if shortCode == "" {
-		return fmt.Errorf("short code cannot be empty")
+// IsValidRedirectStatus checks if a status code can be used to redirect a short URL
+func IsValidRedirectStatus(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
 	}
+}
 
-	if len(shortCode) < MinShortCodeLength || len(shortCode) > MaxShortCodeLength {
-		return fmt.Errorf("short code must be between %d and %d characters", MinShortCodeLength, MaxShortCodeLength)
-	}
+// Errors returned by CheckURLWindow
+var (
+	ErrURLNotYetActive = errors.New("URL is not active yet")
+	ErrURLExpired      = errors.New("URL has expired")
+)
 
-	// Check if it contains only alphanumeric characters
-	matched, err := regexp.MatchString(`^[a-zA-Z0-9]+$`, shortCode)
-	if err != nil {
-		return fmt.Errorf("failed to validate short code format: %w", err)
+// IsURLExpired checks if a URL has expired
+func IsURLExpired(expiresAt *time.Time) bool {
+	if expiresAt == nil {
+		return false
 	}
+	return time.Now().After(*expiresAt)
+}
 
-	if !matched {
-		return fmt.Errorf("short code must contain only alphanumeric characters")
+// IsURLNotYetActive checks if a URL is scheduled to activate in the future
+func IsURLNotYetActive(activatesAt *time.Time) bool {
+	if activatesAt == nil {
+		return false
 	}
+	return time.Now().Before(*activatesAt)
+}
 
-	// Check for reserved short codes
-	reservedCodes := []string{
-		"admin", "api", "www", "mail", "ftp", "blog", "shop", "help",
-		"about", "contact", "terms", "privacy", "login", "register",
-		"dashboard", "profile", "settings", "logout", "search",
+// CheckURLWindow checks that a URL is within its activation window. A nil bound leaves
+// that side of the window open.
+func CheckURLWindow(activatesAt, expiresAt *time.Time) error {
+	if IsURLNotYetActive(activatesAt) {
+		return ErrURLNotYetActive
 	}
-
-	lowerCode := strings.ToLower(shortCode)
-	for _, reserved := range reservedCodes {
-		if lowerCode == reserved {
-			return fmt.Errorf("short code '%s' is reserved", shortCode)
-		}
+	if IsURLExpired(expiresAt) {
+		return ErrURLExpired
 	}
-
 	return nil
 }
 
-// IsURLExpired checks if a URL has expired
-func IsURLExpired(expiresAt *time.Time) bool {
-	if expiresAt == nil {
-		return false
+// ValidateURLWindow checks that a URL's activation time, if any, comes before its expiry
+func ValidateURLWindow(activatesAt, expiresAt *time.Time) error {
+	if activatesAt != nil && expiresAt != nil && !activatesAt.Before(*expiresAt) {
+		return fmt.Errorf("activation time must be before the expiry time")
 	}
-	return time.Now().After(*expiresAt)
+	return nil
 }
 
 // NormalizeURL normalizes a URL by adding protocol if missing
@@ -169,6 +134,72 @@ func NormalizeURL(rawURL string) string {
 	return rawURL
 }
 
+// AppendPath appends a path suffix to the path of rawURL, keeping its query string and fragment
+func AppendPath(rawURL, suffix string) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	suffix = strings.TrimPrefix(suffix, "/")
+	if suffix == "" {
+		return rawURL, nil
+	}
+
+	escapedSuffix := (&url.URL{Path: suffix}).EscapedPath()
+	escapedPath := strings.TrimSuffix(parsedURL.EscapedPath(), "/") + "/" + escapedSuffix
+	path, err := url.PathUnescape(escapedPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+	parsedURL.Path = path
+	parsedURL.RawPath = escapedPath
+	return parsedURL.String(), nil
+}
+
+// MergeQueryParams adds params to the query string of rawURL. Parameters already present
+// in rawURL are kept as they are, and empty values are skipped.
+func MergeQueryParams(rawURL string, params url.Values) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	existing := parsedURL.Query()
+	added := url.Values{}
+	for key, values := range params {
+		if existing.Has(key) {
+			continue
+		}
+		for _, value := range values {
+			if value != "" {
+				added.Add(key, value)
+			}
+		}
+	}
+
+	if len(added) == 0 {
+		return rawURL, nil
+	}
+
+	// Append rather than re-encode so the existing query string is left byte-for-byte intact
+	if parsedURL.RawQuery == "" {
+		parsedURL.RawQuery = added.Encode()
+	} else {
+		parsedURL.RawQuery += "&" + added.Encode()
+	}
+	return parsedURL.String(), nil
+}
+
+// NormalizeHostname lowercases a hostname and strips any port and trailing dot, so Host
+// headers and registered domains compare equal
+func NormalizeHostname(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
 // GetDomainFromURL extracts the domain from a URL
 func GetDomainFromURL(rawURL string) (string, error) {
 	parsedURL, err := url.Parse(rawURL)
@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"strings"
+)
+
+const (
+	// DeviceDesktop is the device class for desktop and laptop browsers
+	DeviceDesktop = "desktop"
+	// DeviceMobile is the device class for phones
+	DeviceMobile = "mobile"
+	// DeviceTablet is the device class for tablets
+	DeviceTablet = "tablet"
+	// DeviceBot is the device class for crawlers, link previews and scripted clients
+	DeviceBot = "bot"
+
+	// UnknownValue is used when a User-Agent component cannot be identified
+	UnknownValue = "Other"
+)
+
+// Operating system families reported by ParseUserAgent
+const (
+	OSiOS      = "iOS"
+	OSAndroid  = "Android"
+	OSWindows  = "Windows"
+	OSChromeOS = "Chrome OS"
+	OSMacOS    = "macOS"
+	OSLinux    = "Linux"
+)
+
+// KnownDeviceTypes are the device classes of human clients that rules can target
+var KnownDeviceTypes = []string{DeviceDesktop, DeviceMobile, DeviceTablet}
+
+// KnownOperatingSystems are the operating system families that rules can target
+var KnownOperatingSystems = []string{OSiOS, OSAndroid, OSWindows, OSChromeOS, OSMacOS, OSLinux}
+
+// UserAgentInfo holds the parsed components of a User-Agent header
+type UserAgentInfo struct {
+	Browser    string
+	OS         string
+	DeviceType string
+	IsBot      bool
+}
+
+// botPatterns are lowercase User-Agent fragments that identify non-human clients,
+// including the link-preview fetchers used by chat and social apps
+var botPatterns = []string{
+	"bot", "crawler", "spider", "slurp", "crawl",
+	"facebookexternalhit", "facebookcatalog", "slack-imgproxy", "whatsapp",
+	"skypeuripreview", "embedly", "pinterest", "bingpreview", "quora link preview",
+	"vkshare", "headlesschrome", "preview", "lighthouse",
+	"curl", "wget", "python-requests", "python-urllib", "go-http-client",
+	"okhttp", "java/", "httpclient", "axios", "node-fetch",
+}
+
+// ParseUserAgent extracts browser family, OS and device class from a User-Agent header
+func ParseUserAgent(userAgent string) UserAgentInfo {
+	ua := strings.ToLower(userAgent)
+
+	info := UserAgentInfo{
+		Browser: parseBrowser(ua),
+		OS:      parseOS(ua),
+		IsBot:   isBotUserAgent(ua),
+	}
+
+	if info.IsBot {
+		info.DeviceType = DeviceBot
+	} else {
+		info.DeviceType = parseDeviceType(ua)
+	}
+
+	return info
+}
+
+// isBotUserAgent reports whether a lowercase User-Agent belongs to a non-human client
+func isBotUserAgent(ua string) bool {
+	if strings.TrimSpace(ua) == "" {
+		return true
+	}
+
+	for _, pattern := range botPatterns {
+		if strings.Contains(ua, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseBrowser identifies the browser family. Order matters because most
+// browsers also advertise the engines they are compatible with.
+func parseBrowser(ua string) string {
+	switch {
+	case strings.Contains(ua, "edg/") || strings.Contains(ua, "edge/") || strings.Contains(ua, "edga/") || strings.Contains(ua, "edgios/"):
+		return "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		return "Opera"
+	case strings.Contains(ua, "samsungbrowser"):
+		return "Samsung Internet"
+	case strings.Contains(ua, "firefox/") || strings.Contains(ua, "fxios/"):
+		return "Firefox"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/") || strings.Contains(ua, "chromium/"):
+		return "Chrome"
+	case strings.Contains(ua, "safari/") && strings.Contains(ua, "version/"):
+		return "Safari"
+	case strings.Contains(ua, "msie") || strings.Contains(ua, "trident/"):
+		return "Internet Explorer"
+	default:
+		return UnknownValue
+	}
+}
+
+// parseOS identifies the operating system family
+func parseOS(ua string) string {
+	switch {
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ipod"):
+		return OSiOS
+	case strings.Contains(ua, "android"):
+		return OSAndroid
+	case strings.Contains(ua, "windows"):
+		return OSWindows
+	case strings.Contains(ua, "cros"):
+		return OSChromeOS
+	case strings.Contains(ua, "mac os x") || strings.Contains(ua, "macintosh"):
+		return OSMacOS
+	case strings.Contains(ua, "linux"):
+		return OSLinux
+	default:
+		return UnknownValue
+	}
+}
+
+// parseDeviceType classifies a human client as desktop, mobile or tablet
+func parseDeviceType(ua string) string {
+	switch {
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet"):
+		return DeviceTablet
+	case strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return DeviceTablet
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone") || strings.Contains(ua, "ipod"):
+		return DeviceMobile
+	default:
+		return DeviceDesktop
+	}
+}
+
+// CanonicalDeviceType returns the known device class matching value case-insensitively
+func CanonicalDeviceType(value string) (string, bool) {
+	return canonicalValue(KnownDeviceTypes, value)
+}
+
+// CanonicalOS returns the known operating system family matching value case-insensitively
+func CanonicalOS(value string) (string, bool) {
+	return canonicalValue(KnownOperatingSystems, value)
+}
+
+func canonicalValue(known []string, value string) (string, bool) {
+	for _, candidate := range known {
+		if strings.EqualFold(candidate, strings.TrimSpace(value)) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// GetRefererDomain returns the lowercase host of a Referer header without a leading "www."
+func GetRefererDomain(referer string) string {
+	if referer == "" {
+		return ""
+	}
+
+	domain, err := GetDomainFromURL(referer)
+	if err != nil {
+		return ""
+	}
+
+	domain = strings.ToLower(domain)
+	return strings.TrimPrefix(domain, "www.")
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// VisitorHash returns an anonymous visitor identifier derived from the client IP and
+// User-Agent. The salt and the UTC day are mixed in so the identifier can't be reversed
+// to an IP or correlated across days.
+func VisitorHash(salt, ipAddress, userAgent string, at time.Time) string {
+	hash := sha256.New()
+	hash.Write([]byte(salt))
+	hash.Write([]byte{0})
+	hash.Write([]byte(at.UTC().Format(time.DateOnly)))
+	hash.Write([]byte{0})
+	hash.Write([]byte(ipAddress))
+	hash.Write([]byte{0})
+	hash.Write([]byte(userAgent))
+	return hex.EncodeToString(hash.Sum(nil))
+}